@@ -6,6 +6,8 @@ export namespace audiocapture {
 	    isInput: boolean;
 	    isOutput: boolean;
 	    isDefault: boolean;
+	    isLoopback: boolean;
+	    isSelected: boolean;
 	
 	    static createFrom(source: any = {}) {
 	        return new AudioDevice(source);
@@ -18,6 +20,43 @@ export namespace audiocapture {
 	        this.isInput = source["isInput"];
 	        this.isOutput = source["isOutput"];
 	        this.isDefault = source["isDefault"];
+	        this.isLoopback = source["isLoopback"];
+	        this.isSelected = source["isSelected"];
+	    }
+	}
+
+}
+
+export namespace client {
+	
+	export class PromptPreview {
+	    system: string;
+	    user?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new PromptPreview(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.system = source["system"];
+	        this.user = source["user"];
+	    }
+	}
+	export class PromptSpec {
+	    name: string;
+	    description: string;
+	    placeholders: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new PromptSpec(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.description = source["description"];
+	        this.placeholders = source["placeholders"];
 	    }
 	}
 
@@ -25,8 +64,29 @@ export namespace audiocapture {
 
 export namespace models {
 	
+	export class TranscriptSegment {
+	    start: number;
+	    end: number;
+	    text: string;
+	    speaker?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new TranscriptSegment(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.start = source["start"];
+	        this.end = source["end"];
+	        this.text = source["text"];
+	        this.speaker = source["speaker"];
+	    }
+	}
 	export class AnalyzeInterview {
 	    id: number;
+	    transcript?: string;
+	    segments?: TranscriptSegment[];
+	    prompt_version?: string;
 	    // Go type: time
 	    created_at: any;
 	    // Go type: time
@@ -39,6 +99,9 @@ export namespace models {
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.id = source["id"];
+	        this.transcript = source["transcript"];
+	        this.segments = this.convertValues(source["segments"], TranscriptSegment);
+	        this.prompt_version = source["prompt_version"];
 	        this.created_at = this.convertValues(source["created_at"], null);
 	        this.updated_at = this.convertValues(source["updated_at"], null);
 	    }
@@ -68,6 +131,10 @@ export namespace models {
 	    full_answer: string;
 	    accuracy: number;
 	    reason_unanswered: string;
+	    questioner: string;
+	    answerer: string;
+	    start_time?: number;
+	    end_time?: number;
 	    // Go type: time
 	    created_at: any;
 	    // Go type: time
@@ -85,6 +152,10 @@ export namespace models {
 	        this.full_answer = source["full_answer"];
 	        this.accuracy = source["accuracy"];
 	        this.reason_unanswered = source["reason_unanswered"];
+	        this.questioner = source["questioner"];
+	        this.answerer = source["answerer"];
+	        this.start_time = source["start_time"];
+	        this.end_time = source["end_time"];
 	        this.created_at = this.convertValues(source["created_at"], null);
 	        this.updated_at = this.convertValues(source["updated_at"], null);
 	    }
@@ -110,6 +181,9 @@ export namespace models {
 	export class AnalyzeInterviewWithQA {
 	    id: number;
 	    qa: QuestionAnswer[];
+	    transcript?: string;
+	    segments?: TranscriptSegment[];
+	    prompt_version?: string;
 	    // Go type: time
 	    created_at: any;
 	    // Go type: time
@@ -123,6 +197,9 @@ export namespace models {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.id = source["id"];
 	        this.qa = this.convertValues(source["qa"], QuestionAnswer);
+	        this.transcript = source["transcript"];
+	        this.segments = this.convertValues(source["segments"], TranscriptSegment);
+	        this.prompt_version = source["prompt_version"];
 	        this.created_at = this.convertValues(source["created_at"], null);
 	        this.updated_at = this.convertValues(source["updated_at"], null);
 	    }
@@ -149,6 +226,8 @@ export namespace models {
 	    id: number;
 	    transcript: string;
 	    analysis: number[];
+	    segments?: TranscriptSegment[];
+	    prompt_version?: string;
 	    // Go type: time
 	    created_at: any;
 	    // Go type: time
@@ -163,6 +242,8 @@ export namespace models {
 	        this.id = source["id"];
 	        this.transcript = source["transcript"];
 	        this.analysis = source["analysis"];
+	        this.segments = this.convertValues(source["segments"], TranscriptSegment);
+	        this.prompt_version = source["prompt_version"];
 	        this.created_at = this.convertValues(source["created_at"], null);
 	        this.updated_at = this.convertValues(source["updated_at"], null);
 	    }
@@ -296,61 +377,185 @@ export namespace models {
 		    return a;
 		}
 	}
+	export class PauseMarker {
+	    position: number;
+	    // Go type: time
+	    paused_at: any;
+	    // Go type: time
+	    resumed_at: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new PauseMarker(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.position = source["position"];
+	        this.paused_at = this.convertValues(source["paused_at"], null);
+	        this.resumed_at = this.convertValues(source["resumed_at"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
 
-}
-
-export namespace wails_app {
+	export class Transcript {
+	    text: string;
+	    segments: TranscriptSegment[];
 	
-	export class APIKeyResult {
-	    success: boolean;
-	    message?: string;
-	    apiKey?: string;
-	    lastUpdated?: string;
+	    static createFrom(source: any = {}) {
+	        return new Transcript(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.text = source["text"];
+	        this.segments = this.convertValues(source["segments"], TranscriptSegment);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class JobFailure {
+	    index: number;
+	    start?: number;
+	    end?: number;
+	    error: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new APIKeyResult(source);
+	        return new JobFailure(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.success = source["success"];
-	        this.message = source["message"];
-	        this.apiKey = source["apiKey"];
-	        this.lastUpdated = source["lastUpdated"];
+	        this.index = source["index"];
+	        this.start = source["start"];
+	        this.end = source["end"];
+	        this.error = source["error"];
 	    }
 	}
-	export class CallAnalysisResult {
-	    success: boolean;
-	    message: string;
-	    analysisPath?: string;
+	export class JobChunk {
+	    path: string;
+	    start: number;
+	    duration: number;
+	    overlap?: number;
+	    done: boolean;
+	    transcript: Transcript;
+	    error?: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new CallAnalysisResult(source);
+	        return new JobChunk(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.success = source["success"];
-	        this.message = source["message"];
-	        this.analysisPath = source["analysisPath"];
+	        this.path = source["path"];
+	        this.start = source["start"];
+	        this.duration = source["duration"];
+	        this.overlap = source["overlap"];
+	        this.done = source["done"];
+	        this.transcript = this.convertValues(source["transcript"], Transcript);
+	        this.error = source["error"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class JobBatch {
+	    text: string;
+	    done: boolean;
+	    qa?: QuestionAnswer[];
+	    error?: string;
+	    prompt_version?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new JobBatch(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.text = source["text"];
+	        this.done = source["done"];
+	        this.qa = this.convertValues(source["qa"], QuestionAnswer);
+	        this.error = source["error"];
+	        this.prompt_version = source["prompt_version"];
 	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
 	}
-	export class DeviceResult {
-	    success: boolean;
-	    message?: string;
-	    devices?: audiocapture.AudioDevice[];
-	    device?: audiocapture.AudioDevice;
+	export class JobTranscript {
+	    text: string;
+	    segments: TranscriptSegment[];
 	
 	    static createFrom(source: any = {}) {
-	        return new DeviceResult(source);
+	        return new JobTranscript(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.success = source["success"];
-	        this.message = source["message"];
-	        this.devices = this.convertValues(source["devices"], audiocapture.AudioDevice);
-	        this.device = this.convertValues(source["device"], audiocapture.AudioDevice);
+	        this.text = source["text"];
+	        this.segments = this.convertValues(source["segments"], TranscriptSegment);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -371,87 +576,663 @@ export namespace wails_app {
 		    return a;
 		}
 	}
-	export class FileContent {
-	    name: string;
-	    path: string;
-	    isDir: boolean;
-	    size: number;
-	    extension: string;
-	    content: string;
+	export class Job {
+	    id: number;
+	    type: string;
+	    status: string;
+	    file_path: string;
+	    transcript_path?: string;
+	    language: string;
+	    chunks: JobChunk[];
+	    transcript?: JobTranscript;
+	    batches: JobBatch[];
+	    result_id?: number;
 	    error?: string;
+	    // Go type: time
+	    created_at: any;
+	    // Go type: time
+	    updated_at: any;
 	
 	    static createFrom(source: any = {}) {
-	        return new FileContent(source);
+	        return new Job(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.name = source["name"];
-	        this.path = source["path"];
-	        this.isDir = source["isDir"];
-	        this.size = source["size"];
-	        this.extension = source["extension"];
-	        this.content = source["content"];
+	        this.id = source["id"];
+	        this.type = source["type"];
+	        this.status = source["status"];
+	        this.file_path = source["file_path"];
+	        this.transcript_path = source["transcript_path"];
+	        this.language = source["language"];
+	        this.chunks = this.convertValues(source["chunks"], JobChunk);
+	        this.transcript = this.convertValues(source["transcript"], JobTranscript);
+	        this.batches = this.convertValues(source["batches"], JobBatch);
+	        this.result_id = source["result_id"];
 	        this.error = source["error"];
+	        this.created_at = this.convertValues(source["created_at"], null);
+	        this.updated_at = this.convertValues(source["updated_at"], null);
 	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
 	}
-	export class FileInfo {
-	    name: string;
-	    path: string;
-	    isDir: boolean;
-	    size: number;
-	    extension: string;
+	export class CostEstimate {
+	    audio_seconds: number;
+	    transcribe: number;
+	    diarize: number;
+	    analyze: number;
+	    total: number;
 	
 	    static createFrom(source: any = {}) {
-	        return new FileInfo(source);
+	        return new CostEstimate(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.name = source["name"];
-	        this.path = source["path"];
-	        this.isDir = source["isDir"];
-	        this.size = source["size"];
-	        this.extension = source["extension"];
+	        this.audio_seconds = source["audio_seconds"];
+	        this.transcribe = source["transcribe"];
+	        this.diarize = source["diarize"];
+	        this.analyze = source["analyze"];
+	        this.total = source["total"];
 	    }
 	}
-	export class RecordingResult {
-	    success: boolean;
-	    message: string;
-	    filePath?: string;
-	    duration?: number;
-	    dataSize?: number;
+	export class UsageTotal {
+	    key: string;
+	    requests: number;
+	    audio_seconds: number;
+	    prompt_tokens: number;
+	    completion_tokens: number;
+	    cost: number;
 	
 	    static createFrom(source: any = {}) {
-	        return new RecordingResult(source);
+	        return new UsageTotal(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.success = source["success"];
-	        this.message = source["message"];
-	        this.filePath = source["filePath"];
-	        this.duration = source["duration"];
-	        this.dataSize = source["dataSize"];
+	        this.key = source["key"];
+	        this.requests = source["requests"];
+	        this.audio_seconds = source["audio_seconds"];
+	        this.prompt_tokens = source["prompt_tokens"];
+	        this.completion_tokens = source["completion_tokens"];
+	        this.cost = source["cost"];
 	    }
 	}
-	export class TranscriptionResult {
-	    success: boolean;
-	    message: string;
-	    transcriptPath?: string;
-	    analysisPath?: string;
+
+	export class PromptTemplate {
+	    id: number;
+	    name: string;
+	    language: string;
+	    version: number;
+	    label?: string;
+	    body: string;
+	    // Go type: time
+	    created_at: any;
 	
 	    static createFrom(source: any = {}) {
-	        return new TranscriptionResult(source);
+	        return new PromptTemplate(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.success = source["success"];
-	        this.message = source["message"];
-	        this.transcriptPath = source["transcriptPath"];
-	        this.analysisPath = source["analysisPath"];
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.language = source["language"];
+	        this.version = source["version"];
+	        this.label = source["label"];
+	        this.body = source["body"];
+	        this.created_at = this.convertValues(source["created_at"], null);
 	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class InterviewAnalysis {
+	    id: number;
+	    interview_id: number;
+	    version: number;
+	    model?: string;
+	    prompt_version?: string;
+	    language?: string;
+	    qa: QuestionAnswer[];
+	    // Go type: time
+	    created_at: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new InterviewAnalysis(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.interview_id = source["interview_id"];
+	        this.version = source["version"];
+	        this.model = source["model"];
+	        this.prompt_version = source["prompt_version"];
+	        this.language = source["language"];
+	        this.qa = this.convertValues(source["qa"], QuestionAnswer);
+	        this.created_at = this.convertValues(source["created_at"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+}
+
+export namespace pipeline {
+	
+	export class ReanalyzeOptions {
+	    model?: string;
+	    prompt_version?: number;
+	    language?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ReanalyzeOptions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.model = source["model"];
+	        this.prompt_version = source["prompt_version"];
+	        this.language = source["language"];
+	    }
+	}
+
+}
+
+export namespace wails_app {
+	
+	export class APIKeyResult {
+	    success: boolean;
+	    message?: string;
+	    apiKey?: string;
+	    lastUpdated?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new APIKeyResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.success = source["success"];
+	        this.message = source["message"];
+	        this.apiKey = source["apiKey"];
+	        this.lastUpdated = source["lastUpdated"];
+	    }
+	}
+	export class CallAnalysisResult {
+	    success: boolean;
+	    message: string;
+	    analysisPath?: string;
+	    jobId?: number;
+	    partial?: boolean;
+	    failedChunks?: models.JobFailure[];
+	    failedBatches?: models.JobFailure[];
+	
+	    static createFrom(source: any = {}) {
+	        return new CallAnalysisResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.success = source["success"];
+	        this.message = source["message"];
+	        this.analysisPath = source["analysisPath"];
+	        this.jobId = source["jobId"];
+	        this.partial = source["partial"];
+	        this.failedChunks = this.convertValues(source["failedChunks"], models.JobFailure);
+	        this.failedBatches = this.convertValues(source["failedBatches"], models.JobFailure);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class CostEstimateResult {
+	    success: boolean;
+	    message: string;
+	    estimate?: models.CostEstimate;
+	    month_spent: number;
+	    monthly_limit?: number;
+	    job_limit?: number;
+	    within_budget: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new CostEstimateResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.success = source["success"];
+	        this.message = source["message"];
+	        this.estimate = this.convertValues(source["estimate"], models.CostEstimate);
+	        this.month_spent = source["month_spent"];
+	        this.monthly_limit = source["monthly_limit"];
+	        this.job_limit = source["job_limit"];
+	        this.within_budget = source["within_budget"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class DeviceResult {
+	    success: boolean;
+	    message?: string;
+	    devices?: audiocapture.AudioDevice[];
+	    device?: audiocapture.AudioDevice;
+	
+	    static createFrom(source: any = {}) {
+	        return new DeviceResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.success = source["success"];
+	        this.message = source["message"];
+	        this.devices = this.convertValues(source["devices"], audiocapture.AudioDevice);
+	        this.device = this.convertValues(source["device"], audiocapture.AudioDevice);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class FileContent {
+	    name: string;
+	    path: string;
+	    isDir: boolean;
+	    size: number;
+	    extension: string;
+	    content: string;
+	    error?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new FileContent(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.path = source["path"];
+	        this.isDir = source["isDir"];
+	        this.size = source["size"];
+	        this.extension = source["extension"];
+	        this.content = source["content"];
+	        this.error = source["error"];
+	    }
+	}
+	export class FileInfo {
+	    name: string;
+	    path: string;
+	    isDir: boolean;
+	    size: number;
+	    extension: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new FileInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.path = source["path"];
+	        this.isDir = source["isDir"];
+	        this.size = source["size"];
+	        this.extension = source["extension"];
+	    }
+	}
+	export class JobResult {
+	    success: boolean;
+	    message: string;
+	    job?: models.Job;
+	
+	    static createFrom(source: any = {}) {
+	        return new JobResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.success = source["success"];
+	        this.message = source["message"];
+	        this.job = this.convertValues(source["job"], models.Job);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class PromptPreviewResult {
+	    success: boolean;
+	    message?: string;
+	    preview?: client.PromptPreview;
+	
+	    static createFrom(source: any = {}) {
+	        return new PromptPreviewResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.success = source["success"];
+	        this.message = source["message"];
+	        this.preview = this.convertValues(source["preview"], client.PromptPreview);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class PromptTemplateResult {
+	    success: boolean;
+	    message?: string;
+	    template?: models.PromptTemplate;
+	
+	    static createFrom(source: any = {}) {
+	        return new PromptTemplateResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.success = source["success"];
+	        this.message = source["message"];
+	        this.template = this.convertValues(source["template"], models.PromptTemplate);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class PromptVersionsResult {
+	    success: boolean;
+	    message?: string;
+	    current?: models.PromptTemplate;
+	    versions?: models.PromptTemplate[];
+	
+	    static createFrom(source: any = {}) {
+	        return new PromptVersionsResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.success = source["success"];
+	        this.message = source["message"];
+	        this.current = this.convertValues(source["current"], models.PromptTemplate);
+	        this.versions = this.convertValues(source["versions"], models.PromptTemplate);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ReanalyzeResult {
+	    success: boolean;
+	    message: string;
+	    interview?: models.AnalyzeInterviewWithQA;
+	
+	    static createFrom(source: any = {}) {
+	        return new ReanalyzeResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.success = source["success"];
+	        this.message = source["message"];
+	        this.interview = this.convertValues(source["interview"], models.AnalyzeInterviewWithQA);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class RecordingResult {
+	    success: boolean;
+	    message: string;
+	    filePath?: string;
+	    duration?: number;
+	    dataSize?: number;
+	    micTrackPath?: string;
+	    systemTrackPath?: string;
+	    isRecording: boolean;
+	    isPaused: boolean;
+	    pauses?: models.PauseMarker[];
+	
+	    static createFrom(source: any = {}) {
+	        return new RecordingResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.success = source["success"];
+	        this.message = source["message"];
+	        this.filePath = source["filePath"];
+	        this.duration = source["duration"];
+	        this.dataSize = source["dataSize"];
+	        this.micTrackPath = source["micTrackPath"];
+	        this.systemTrackPath = source["systemTrackPath"];
+	        this.isRecording = source["isRecording"];
+	        this.isPaused = source["isPaused"];
+	        this.pauses = this.convertValues(source["pauses"], models.PauseMarker);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class TranscriptionResult {
+	    success: boolean;
+	    message: string;
+	    transcriptPath?: string;
+	    analysisPath?: string;
+	    jobId?: number;
+	    partial?: boolean;
+	    failedChunks?: models.JobFailure[];
+	    failedBatches?: models.JobFailure[];
+	
+	    static createFrom(source: any = {}) {
+	        return new TranscriptionResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.success = source["success"];
+	        this.message = source["message"];
+	        this.transcriptPath = source["transcriptPath"];
+	        this.analysisPath = source["analysisPath"];
+	        this.jobId = source["jobId"];
+	        this.partial = source["partial"];
+	        this.failedChunks = this.convertValues(source["failedChunks"], models.JobFailure);
+	        this.failedBatches = this.convertValues(source["failedBatches"], models.JobFailure);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
 	}
 
 }
@@ -2,6 +2,10 @@
 // This file is automatically generated. DO NOT EDIT
 import {wails_app} from '../models';
 import {models} from '../models';
+import {client} from '../models';
+import {pipeline} from '../models';
+
+export function CancelProcessing(arg1:number):Promise<wails_app.JobResult>;
 
 export function DeleteCallAPI(arg1:number):Promise<void>;
 
@@ -9,6 +13,8 @@ export function DeleteInterviewAPI(arg1:number):Promise<void>;
 
 export function DeleteOpenAIAPIKey():Promise<wails_app.APIKeyResult>;
 
+export function EstimateCostAPI(arg1:string,arg2:string):Promise<wails_app.CostEstimateResult>;
+
 export function GetAllCallsAPI(arg1:number,arg2:number):Promise<Array<models.Call>>;
 
 export function GetAllInterviewAnalyticsAPI(arg1:string,arg2:string):Promise<Array<models.InterviewAnalytics>>;
@@ -17,6 +23,8 @@ export function GetAllInterviewsAPI(arg1:string,arg2:string):Promise<Array<model
 
 export function GetCallAPI(arg1:number):Promise<models.Call>;
 
+export function GetCallUsageAPI(arg1:number):Promise<models.UsageTotal>;
+
 export function GetCallsByDateRangeAPI(arg1:string,arg2:string):Promise<Array<models.Call>>;
 
 export function GetFiles():Promise<Array<wails_app.FileInfo>>;
@@ -29,26 +37,52 @@ export function GetInputDevices():Promise<wails_app.DeviceResult>;
 
 export function GetInterviewAPI(arg1:number):Promise<models.AnalyzeInterviewWithQA>;
 
+export function GetInterviewAnalysesAPI(arg1:number):Promise<Array<models.InterviewAnalysis>>;
+
 export function GetInterviewAnalyticsAPI(arg1:number):Promise<models.InterviewAnalytics>;
 
+export function GetInterviewUsageAPI(arg1:number):Promise<models.UsageTotal>;
+
+export function GetJobsAPI(arg1:number):Promise<Array<models.Job>>;
+
 export function GetOpenAIAPIKey():Promise<wails_app.APIKeyResult>;
 
+export function GetPromptSpecsAPI():Promise<Array<client.PromptSpec>>;
+
+export function GetPromptVersionsAPI(arg1:string,arg2:string):Promise<wails_app.PromptVersionsResult>;
+
 export function GetRecordingStatus():Promise<wails_app.RecordingResult>;
 
+export function GetUsageTotalsAPI(arg1:string,arg2:string,arg3:string):Promise<Array<models.UsageTotal>>;
+
 export function GetWebSocketURL():Promise<string>;
 
 export function Greet(arg1:string):Promise<string>;
 
+export function PauseAudioRecording():Promise<wails_app.RecordingResult>;
+
 export function PickFile():Promise<string>;
 
+export function PreviewPromptAPI(arg1:string,arg2:string,arg3:string):Promise<wails_app.PromptPreviewResult>;
+
 export function ProcessFile(arg1:string):Promise<wails_app.FileInfo>;
 
-export function ProcessFileForCallAnalysis(arg1:string):Promise<wails_app.CallAnalysisResult>;
+export function ProcessFileForCallAnalysis(arg1:string,arg2:string):Promise<wails_app.CallAnalysisResult>;
 
-export function ProcessFileForTranscription(arg1:string):Promise<wails_app.TranscriptionResult>;
+export function ProcessFileForTranscription(arg1:string,arg2:string):Promise<wails_app.TranscriptionResult>;
 
 export function ReadFileContent(arg1:string):Promise<wails_app.FileContent>;
 
+export function ReanalyzeInterviewAPI(arg1:number,arg2:pipeline.ReanalyzeOptions):Promise<wails_app.ReanalyzeResult>;
+
+export function RenameCallSpeakerAPI(arg1:number,arg2:string,arg3:string):Promise<models.Call>;
+
+export function RenameInterviewSpeakerAPI(arg1:number,arg2:string,arg3:string):Promise<models.AnalyzeInterviewWithQA>;
+
+export function ResumeAudioRecording():Promise<wails_app.RecordingResult>;
+
+export function RetryJob(arg1:number):Promise<wails_app.JobResult>;
+
 export function SaveAndProcessRecording(arg1:string):Promise<wails_app.TranscriptionResult>;
 
 export function SaveAndProcessRecordingForCall(arg1:string):Promise<wails_app.CallAnalysisResult>;
@@ -59,6 +93,8 @@ export function SaveInterviewAPI(arg1:models.AnalyzeInterviewWithQA):Promise<num
 
 export function SaveOpenAIAPIKey(arg1:string):Promise<wails_app.APIKeyResult>;
 
+export function SavePromptTemplateAPI(arg1:string,arg2:string,arg3:string,arg4:string):Promise<wails_app.PromptTemplateResult>;
+
 export function SaveRecording(arg1:string):Promise<wails_app.RecordingResult>;
 
 export function SetAudioInputDevice(arg1:string):Promise<wails_app.DeviceResult>;
@@ -2,6 +2,10 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
 
+export function CancelProcessing(arg1) {
+  return window['go']['wails_app']['App']['CancelProcessing'](arg1);
+}
+
 export function DeleteCallAPI(arg1) {
   return window['go']['wails_app']['App']['DeleteCallAPI'](arg1);
 }
@@ -14,6 +18,10 @@ export function DeleteOpenAIAPIKey() {
   return window['go']['wails_app']['App']['DeleteOpenAIAPIKey']();
 }
 
+export function EstimateCostAPI(arg1, arg2) {
+  return window['go']['wails_app']['App']['EstimateCostAPI'](arg1, arg2);
+}
+
 export function GetAllCallsAPI(arg1, arg2) {
   return window['go']['wails_app']['App']['GetAllCallsAPI'](arg1, arg2);
 }
@@ -30,6 +38,10 @@ export function GetCallAPI(arg1) {
   return window['go']['wails_app']['App']['GetCallAPI'](arg1);
 }
 
+export function GetCallUsageAPI(arg1) {
+  return window['go']['wails_app']['App']['GetCallUsageAPI'](arg1);
+}
+
 export function GetCallsByDateRangeAPI(arg1, arg2) {
   return window['go']['wails_app']['App']['GetCallsByDateRangeAPI'](arg1, arg2);
 }
@@ -54,18 +66,42 @@ export function GetInterviewAPI(arg1) {
   return window['go']['wails_app']['App']['GetInterviewAPI'](arg1);
 }
 
+export function GetInterviewAnalysesAPI(arg1) {
+  return window['go']['wails_app']['App']['GetInterviewAnalysesAPI'](arg1);
+}
+
 export function GetInterviewAnalyticsAPI(arg1) {
   return window['go']['wails_app']['App']['GetInterviewAnalyticsAPI'](arg1);
 }
 
+export function GetInterviewUsageAPI(arg1) {
+  return window['go']['wails_app']['App']['GetInterviewUsageAPI'](arg1);
+}
+
+export function GetJobsAPI(arg1) {
+  return window['go']['wails_app']['App']['GetJobsAPI'](arg1);
+}
+
 export function GetOpenAIAPIKey() {
   return window['go']['wails_app']['App']['GetOpenAIAPIKey']();
 }
 
+export function GetPromptSpecsAPI() {
+  return window['go']['wails_app']['App']['GetPromptSpecsAPI']();
+}
+
+export function GetPromptVersionsAPI(arg1, arg2) {
+  return window['go']['wails_app']['App']['GetPromptVersionsAPI'](arg1, arg2);
+}
+
 export function GetRecordingStatus() {
   return window['go']['wails_app']['App']['GetRecordingStatus']();
 }
 
+export function GetUsageTotalsAPI(arg1, arg2, arg3) {
+  return window['go']['wails_app']['App']['GetUsageTotalsAPI'](arg1, arg2, arg3);
+}
+
 export function GetWebSocketURL() {
   return window['go']['wails_app']['App']['GetWebSocketURL']();
 }
@@ -74,26 +110,54 @@ export function Greet(arg1) {
   return window['go']['wails_app']['App']['Greet'](arg1);
 }
 
+export function PauseAudioRecording() {
+  return window['go']['wails_app']['App']['PauseAudioRecording']();
+}
+
 export function PickFile() {
   return window['go']['wails_app']['App']['PickFile']();
 }
 
+export function PreviewPromptAPI(arg1, arg2, arg3) {
+  return window['go']['wails_app']['App']['PreviewPromptAPI'](arg1, arg2, arg3);
+}
+
 export function ProcessFile(arg1) {
   return window['go']['wails_app']['App']['ProcessFile'](arg1);
 }
 
-export function ProcessFileForCallAnalysis(arg1) {
-  return window['go']['wails_app']['App']['ProcessFileForCallAnalysis'](arg1);
+export function ProcessFileForCallAnalysis(arg1, arg2) {
+  return window['go']['wails_app']['App']['ProcessFileForCallAnalysis'](arg1, arg2);
 }
 
-export function ProcessFileForTranscription(arg1) {
-  return window['go']['wails_app']['App']['ProcessFileForTranscription'](arg1);
+export function ProcessFileForTranscription(arg1, arg2) {
+  return window['go']['wails_app']['App']['ProcessFileForTranscription'](arg1, arg2);
 }
 
 export function ReadFileContent(arg1) {
   return window['go']['wails_app']['App']['ReadFileContent'](arg1);
 }
 
+export function ReanalyzeInterviewAPI(arg1, arg2) {
+  return window['go']['wails_app']['App']['ReanalyzeInterviewAPI'](arg1, arg2);
+}
+
+export function RenameCallSpeakerAPI(arg1, arg2, arg3) {
+  return window['go']['wails_app']['App']['RenameCallSpeakerAPI'](arg1, arg2, arg3);
+}
+
+export function RenameInterviewSpeakerAPI(arg1, arg2, arg3) {
+  return window['go']['wails_app']['App']['RenameInterviewSpeakerAPI'](arg1, arg2, arg3);
+}
+
+export function ResumeAudioRecording() {
+  return window['go']['wails_app']['App']['ResumeAudioRecording']();
+}
+
+export function RetryJob(arg1) {
+  return window['go']['wails_app']['App']['RetryJob'](arg1);
+}
+
 export function SaveAndProcessRecording(arg1) {
   return window['go']['wails_app']['App']['SaveAndProcessRecording'](arg1);
 }
@@ -114,6 +178,10 @@ export function SaveOpenAIAPIKey(arg1) {
   return window['go']['wails_app']['App']['SaveOpenAIAPIKey'](arg1);
 }
 
+export function SavePromptTemplateAPI(arg1, arg2, arg3, arg4) {
+  return window['go']['wails_app']['App']['SavePromptTemplateAPI'](arg1, arg2, arg3, arg4);
+}
+
 export function SaveRecording(arg1) {
   return window['go']['wails_app']['App']['SaveRecording'](arg1);
 }
@@ -2,16 +2,32 @@ package audiocapture
 
 import (
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"log"
+	"os"
+	"path/filepath"
+	"regexp"
 	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/gen2brain/malgo"
 
+	"github.com/mrbelka12000/interview_parser/internal/config"
+	"github.com/mrbelka12000/interview_parser/internal/models"
+	"github.com/mrbelka12000/interview_parser/internal/parser"
 	"github.com/mrbelka12000/interview_parser/internal/wav"
 )
 
+// files of a recording in progress
+const (
+	mixFileName    = "mix.wav"
+	micFileName    = "mic.wav"
+	systemFileName = "system.wav"
+)
+
 // AudioDevice represents an audio device information
 type AudioDevice struct {
 	ID        string `json:"id"`
@@ -19,6 +35,9 @@ type AudioDevice struct {
 	IsInput   bool   `json:"isInput"`
 	IsOutput  bool   `json:"isOutput"`
 	IsDefault bool   `json:"isDefault"`
+	// IsLoopback marks the devices carrying the system audio, e.g. BlackHole or a PulseAudio monitor source
+	IsLoopback bool `json:"isLoopback"`
+	IsSelected bool `json:"isSelected"`
 }
 
 // AudioCapturer handles audio capturing and saving
@@ -34,19 +53,51 @@ type AudioCapturer struct {
 	micCh chan []byte // NEW: mic PCM chunks
 	bhCh  chan []byte // NEW: BlackHole PCM chunks
 
+	loopbackPattern *regexp.Regexp
+	micDeviceID     string
+	loopbackID      string
+
 	mx sync.Mutex
 
-	capturedData  []byte
-	isCapturing   bool
+	// isCapturing and isPaused are read by the device callbacks, which run on the audio threads
+	isCapturing atomic.Bool
+	isPaused    atomic.Bool
+	pauses      []models.PauseMarker
+	// done is closed when the mixer goroutine has written everything
+	done chan struct{}
+
+	// the recording is streamed into files under dir, so it is neither kept in memory nor lost on a crash
+	dir          string
+	recordingDir string
+	captured     *wav.StreamWriter
+	dataSize     int
+	writeErr     error
+
+	// separateTracks keeps the mic and the system audio apart in addition to the mix
+	separateTracks bool
+	micTrack       *wav.StreamWriter
+	systemTrack    *wav.StreamWriter
+
 	sampleRate    uint32
 	channels      uint32
 	bitsPerSample uint16
 
-	wav *wav.Writer
+	speakers *parser.SpeakerTracker
 }
 
-// NewAudioCapturer creates a new audio recorder instance
-func NewAudioCapturer(sampleRate uint32, channels uint32, bitsPerSample uint16) (*AudioCapturer, error) {
+// NewAudioCapturer creates a new audio recorder instance, recordings in progress are written to dir
+func NewAudioCapturer(cfg config.AudioConfig, dir string) (*AudioCapturer, error) {
+	var (
+		sampleRate    = cfg.AudioSampleRate
+		channels      = cfg.AudioChannels
+		bitsPerSample = cfg.AudioBitrate
+	)
+
+	loopbackPattern, err := regexp.Compile("(?i)" + cfg.AudioLoopbackDevicePattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid loopback device pattern: %w", err)
+	}
+
 	defaultCtx, err := malgo.InitContext(nil, malgo.ContextConfig{}, func(message string) {
 		log.Printf("[I] AUDIO_CAPTURER <%v>\n", strings.TrimSuffix(message, "\n"))
 	})
@@ -61,16 +112,17 @@ func NewAudioCapturer(sampleRate uint32, channels uint32, bitsPerSample uint16)
 	defaultConfig.Alsa.NoMMap = 1
 
 	ar := &AudioCapturer{
-		defaultCtx:    defaultCtx,
-		defaultConfig: defaultConfig,
-		capturedData:  make([]byte, 0),
-		isCapturing:   false,
-		sampleRate:    sampleRate,
-		channels:      channels,
-		bitsPerSample: bitsPerSample,
-		micCh:         make(chan []byte, 64), // NEW
-		bhCh:          make(chan []byte, 64), // NEW
-		wav:           wav.NewWriter(sampleRate, channels, bitsPerSample),
+		defaultCtx:      defaultCtx,
+		defaultConfig:   defaultConfig,
+		dir:             dir,
+		separateTracks:  cfg.AudioSeparateTracks,
+		sampleRate:      sampleRate,
+		channels:        channels,
+		bitsPerSample:   bitsPerSample,
+		micCh:           make(chan []byte, 64), // NEW
+		bhCh:            make(chan []byte, 64), // NEW
+		speakers:        parser.NewSpeakerTracker(sampleRate, channels),
+		loopbackPattern: loopbackPattern,
 	}
 
 	blackHoleCtx, err := malgo.InitContext(nil, malgo.ContextConfig{}, func(message string) {
@@ -85,8 +137,13 @@ func NewAudioCapturer(sampleRate uint32, channels uint32, bitsPerSample uint16)
 	blackHoleConfig.Capture.Format = malgo.FormatS16 // 16-bit PCM
 	blackHoleConfig.Capture.Channels = channels
 	blackHoleConfig.SampleRate = sampleRate
-	device := ar.getBlackHoleDevice()
-	blackHoleConfig.Capture.DeviceID = device.ID.Pointer()
+	if device, ok := ar.getLoopbackDevice(); ok {
+		blackHoleConfig.Capture.DeviceID = device.ID.Pointer()
+		ar.loopbackID = device.ID.String()
+		log.Printf("[I] Loopback device: %s (%s)\n", device.Name(), ar.loopbackID)
+	} else {
+		log.Printf("[I] No loopback device matches %q, the system audio will not be told apart\n", cfg.AudioLoopbackDevicePattern)
+	}
 	blackHoleConfig.Alsa.NoMMap = 1
 
 	ar.blackHoleConfig = blackHoleConfig
@@ -94,30 +151,61 @@ func NewAudioCapturer(sampleRate uint32, channels uint32, bitsPerSample uint16)
 	return ar, nil
 }
 
-func (ar *AudioCapturer) getBlackHoleDevice() (device malgo.DeviceInfo) {
+// getLoopbackDevice finds the capture device carrying the system audio.
+// Linux has a monitor source per output, so the one of the default output is preferred.
+func (ar *AudioCapturer) getLoopbackDevice() (malgo.DeviceInfo, bool) {
 	captureInfos, err := ar.blackHoleCtx.Devices(malgo.Capture)
 	if err != nil {
-		return
+		return malgo.DeviceInfo{}, false
 	}
 
+	var matches []malgo.DeviceInfo
 	for _, captureInfo := range captureInfos {
-		if strings.Contains(strings.ToLower(captureInfo.Name()), "blackhole") {
-			return captureInfo
+		if ar.isLoopback(captureInfo) {
+			matches = append(matches, captureInfo)
+		}
+	}
+	if len(matches) == 0 {
+		return malgo.DeviceInfo{}, false
+	}
+
+	playbackInfos, err := ar.blackHoleCtx.Devices(malgo.Playback)
+	if err == nil {
+		for _, playbackInfo := range playbackInfos {
+			if playbackInfo.IsDefault == 0 {
+				continue
+			}
+			for _, match := range matches {
+				if strings.Contains(match.Name(), playbackInfo.Name()) {
+					return match, true
+				}
+			}
 		}
 	}
 
-	return
+	return matches[0], true
+}
+
+// isLoopback reports whether the capture device carries the system audio
+func (ar *AudioCapturer) isLoopback(info malgo.DeviceInfo) bool {
+	return ar.loopbackPattern.MatchString(info.Name())
 }
 
 // Start begins capturing audio from the mic + BlackHole and merges them
 func (ar *AudioCapturer) Start() error {
-	if ar.isCapturing {
+	if ar.isCapturing.Load() {
 		return fmt.Errorf("capturing is already in progress")
 	}
 
 	// Clear previous capturing data
-	ar.capturedData = make([]byte, 0)
-	ar.isCapturing = true
+	if err := ar.openFiles(); err != nil {
+		return err
+	}
+	ar.speakers = parser.NewSpeakerTracker(ar.sampleRate, ar.channels)
+	ar.done = make(chan struct{})
+	ar.pauses = nil
+	ar.isPaused.Store(false)
+	ar.isCapturing.Store(true)
 
 	// Recreate channels in case of multiple capturing
 	ar.micCh = make(chan []byte, 64)
@@ -126,7 +214,7 @@ func (ar *AudioCapturer) Start() error {
 	// --- MIC CALLBACK ---
 	micCallbacks := malgo.DeviceCallbacks{
 		Data: func(_, in []byte, _ uint32) {
-			if !ar.isCapturing || len(in) == 0 {
+			if !ar.isCapturing.Load() || ar.isPaused.Load() || len(in) == 0 {
 				return
 			}
 			buf := make([]byte, len(in))
@@ -142,7 +230,7 @@ func (ar *AudioCapturer) Start() error {
 	// --- BLACKHOLE CALLBACK ---
 	bhCallbacks := malgo.DeviceCallbacks{
 		Data: func(_, in []byte, _ uint32) {
-			if !ar.isCapturing || len(in) == 0 {
+			if !ar.isCapturing.Load() || ar.isPaused.Load() || len(in) == 0 {
 				return
 			}
 			buf := make([]byte, len(in))
@@ -158,7 +246,8 @@ func (ar *AudioCapturer) Start() error {
 	// Init mic device
 	micDevice, err := malgo.InitDevice(ar.defaultCtx.Context, ar.defaultConfig, micCallbacks)
 	if err != nil {
-		ar.isCapturing = false
+		ar.isCapturing.Store(false)
+		ar.discardFiles()
 		return fmt.Errorf("failed to initialize mic device: %w", err)
 	}
 	ar.micDevice = micDevice
@@ -166,7 +255,8 @@ func (ar *AudioCapturer) Start() error {
 	// Init BlackHole device
 	bhDevice, err := malgo.InitDevice(ar.blackHoleCtx.Context, ar.blackHoleConfig, bhCallbacks)
 	if err != nil {
-		ar.isCapturing = false
+		ar.isCapturing.Store(false)
+		ar.discardFiles()
 		ar.micDevice.Uninit()
 		ar.micDevice = nil
 		return fmt.Errorf("failed to initialize BlackHole device: %w", err)
@@ -175,7 +265,8 @@ func (ar *AudioCapturer) Start() error {
 
 	// Start both devices
 	if err := ar.micDevice.Start(); err != nil {
-		ar.isCapturing = false
+		ar.isCapturing.Store(false)
+		ar.discardFiles()
 		ar.micDevice.Uninit()
 		ar.blackHoleDevice.Uninit()
 		ar.micDevice = nil
@@ -184,7 +275,8 @@ func (ar *AudioCapturer) Start() error {
 	}
 
 	if err := ar.blackHoleDevice.Start(); err != nil {
-		ar.isCapturing = false
+		ar.isCapturing.Store(false)
+		ar.discardFiles()
 		ar.micDevice.Stop()
 		ar.micDevice.Uninit()
 		ar.blackHoleDevice.Uninit()
@@ -195,8 +287,10 @@ func (ar *AudioCapturer) Start() error {
 
 	log.Printf("[I] Capturing started... Sample Rate: %d Hz, Channels: %d\n", ar.sampleRate, ar.channels)
 
-	// Mixer goroutine: merge mic + BlackHole into capturedData
+	// Mixer goroutine: merge mic + BlackHole into the recording file
 	go func() {
+		defer close(ar.done)
+
 		for {
 			micBuf, ok1 := <-ar.micCh
 			bhBuf, ok2 := <-ar.bhCh
@@ -206,7 +300,16 @@ func (ar *AudioCapturer) Start() error {
 			}
 
 			ar.mx.Lock()
-			ar.capturedData = append(ar.capturedData, mixInt16Stereo(micBuf, bhBuf)...)
+			mixed := mixInt16Stereo(micBuf, bhBuf)
+			ar.write(ar.captured, mixed)
+			ar.dataSize += len(mixed)
+			if ar.micTrack != nil {
+				// both tracks grow by the same length to stay aligned with the mix
+				size := max(len(micBuf), len(bhBuf))
+				ar.write(ar.micTrack, padded(micBuf, size))
+				ar.write(ar.systemTrack, padded(bhBuf, size))
+			}
+			ar.speakers.Add(micBuf, bhBuf)
 			ar.mx.Unlock()
 		}
 	}()
@@ -216,11 +319,15 @@ func (ar *AudioCapturer) Start() error {
 
 // Stop stops the audio capture
 func (ar *AudioCapturer) Stop() error {
-	if !ar.isCapturing {
+	if !ar.isCapturing.CompareAndSwap(true, false) {
 		return fmt.Errorf("no capturing in progress")
 	}
 
-	ar.isCapturing = false
+	ar.mx.Lock()
+	if ar.isPaused.Swap(false) {
+		ar.pauses[len(ar.pauses)-1].ResumedAt = time.Now()
+	}
+	ar.mx.Unlock()
 
 	// Stop and uninit devices
 	if ar.micDevice != nil {
@@ -242,22 +349,93 @@ func (ar *AudioCapturer) Stop() error {
 		close(ar.bhCh)
 	}
 
-	log.Printf("[I] Capturing stopped. Captured %d bytes\n", len(ar.capturedData))
+	// wait for the mixer to write the rest before the headers are finalized
+	<-ar.done
+
+	ar.mx.Lock()
+	defer ar.mx.Unlock()
+
+	if err := ar.closeFiles(); err != nil {
+		return fmt.Errorf("failed to finish recording: %w", err)
+	}
+
+	log.Printf("[I] Capturing stopped. Captured %d bytes\n", ar.dataSize)
 	return nil
 }
 
+// Pause stops taking audio until Resume, the recording continues in the same file
+func (ar *AudioCapturer) Pause() error {
+	ar.mx.Lock()
+	defer ar.mx.Unlock()
+
+	if !ar.isCapturing.Load() {
+		return fmt.Errorf("no capturing in progress")
+	}
+	if ar.isPaused.Load() {
+		return fmt.Errorf("capturing is already paused")
+	}
+
+	ar.isPaused.Store(true)
+	ar.pauses = append(ar.pauses, models.PauseMarker{
+		Position: ar.duration(ar.dataSize),
+		PausedAt: time.Now(),
+	})
+
+	log.Printf("[I] Capturing paused at %.1f seconds\n", ar.duration(ar.dataSize))
+	return nil
+}
+
+// Resume continues a paused capturing
+func (ar *AudioCapturer) Resume() error {
+	ar.mx.Lock()
+	defer ar.mx.Unlock()
+
+	if !ar.isCapturing.Load() {
+		return fmt.Errorf("no capturing in progress")
+	}
+	if !ar.isPaused.Load() {
+		return fmt.Errorf("capturing is not paused")
+	}
+
+	ar.isPaused.Store(false)
+	ar.pauses[len(ar.pauses)-1].ResumedAt = time.Now()
+
+	log.Println("[I] Capturing resumed")
+	return nil
+}
+
+// Pauses returns the pauses of the recording
+func (ar *AudioCapturer) Pauses() []models.PauseMarker {
+	ar.mx.Lock()
+	defer ar.mx.Unlock()
+
+	pauses := make([]models.PauseMarker, len(ar.pauses))
+	copy(pauses, ar.pauses)
+
+	return pauses
+}
+
 // GetAudioInfo returns information about the captured audio
 func (ar *AudioCapturer) GetAudioInfo() map[string]interface{} {
-	duration := float64(len(ar.capturedData)) / float64(ar.sampleRate*ar.channels*2) // 2 bytes per sample for 16-bit
+	ar.mx.Lock()
+	defer ar.mx.Unlock()
+
 	return map[string]interface{}{
 		"sample_rate":      ar.sampleRate,
 		"channels":         ar.channels,
 		"bits_per_sample":  ar.bitsPerSample,
-		"data_size":        len(ar.capturedData),
-		"duration_seconds": duration,
+		"data_size":        ar.dataSize,
+		"duration_seconds": ar.duration(ar.dataSize),
+		"is_capturing":     ar.isCapturing.Load(),
+		"is_paused":        ar.isPaused.Load(),
 	}
 }
 
+// duration returns the duration of dataSize bytes of captured audio in seconds
+func (ar *AudioCapturer) duration(dataSize int) float64 {
+	return float64(dataSize) / float64(ar.sampleRate*ar.channels*2) // 2 bytes per sample for 16-bit
+}
+
 // GetInputDevices returns a list of available input devices
 func (ar *AudioCapturer) GetInputDevices() ([]AudioDevice, error) {
 	var devices []AudioDevice
@@ -268,16 +446,22 @@ func (ar *AudioCapturer) GetInputDevices() ([]AudioDevice, error) {
 	}
 
 	for _, info := range captureInfos {
-		if strings.Contains(strings.ToLower(info.Name()), "blackhole") || strings.Contains(strings.ToLower(info.Name()), "pods") {
+		if strings.Contains(strings.ToLower(info.Name()), "pods") {
 			continue
 		}
 
+		var (
+			id       = info.ID.String()
+			loopback = ar.isLoopback(info)
+		)
 		device := AudioDevice{
-			ID:        info.ID.String(),
-			Name:      info.Name(),
-			IsInput:   true,
-			IsOutput:  false,
-			IsDefault: info.IsDefault > 0,
+			ID:         id,
+			Name:       info.Name(),
+			IsInput:    true,
+			IsOutput:   false,
+			IsDefault:  info.IsDefault > 0,
+			IsLoopback: loopback,
+			IsSelected: (loopback && id == ar.loopbackID) || (!loopback && id == ar.micDeviceID),
 		}
 		devices = append(devices, device)
 	}
@@ -285,9 +469,10 @@ func (ar *AudioCapturer) GetInputDevices() ([]AudioDevice, error) {
 	return devices, nil
 }
 
-// SetInputDeviceByID sets the audio input device by device ID for capturing
+// SetInputDeviceByID sets the audio input device by device ID for capturing.
+// A loopback device replaces the system audio source, any other device replaces the mic.
 func (ar *AudioCapturer) SetInputDeviceByID(deviceID string) error {
-	if ar.isCapturing {
+	if ar.isCapturing.Load() {
 		return fmt.Errorf("cannot change input device while capturing")
 	}
 
@@ -298,8 +483,16 @@ func (ar *AudioCapturer) SetInputDeviceByID(deviceID string) error {
 
 	for _, info := range infos {
 		if info.ID.String() == deviceID {
+			if ar.isLoopback(info) {
+				ar.blackHoleConfig.Capture.DeviceID = info.ID.Pointer()
+				ar.loopbackID = deviceID
+				log.Printf("[I] Loopback device set to: %s (%s)\n", info.Name(), deviceID)
+				return nil
+			}
+
 			// Update device config with the specific device
 			ar.defaultConfig.Capture.DeviceID = info.ID.Pointer()
+			ar.micDeviceID = deviceID
 			log.Printf("[I] Input device set to: %s (%s)\n", info.Name(), info.ID.String())
 			return nil
 		}
@@ -308,8 +501,156 @@ func (ar *AudioCapturer) SetInputDeviceByID(deviceID string) error {
 	return fmt.Errorf("input device with ID %s not found", deviceID)
 }
 
+// SpeakerTurns returns who spoke when in the captured audio, told apart by the mic and the system streams
+func (ar *AudioCapturer) SpeakerTurns() []models.SpeakerTurn {
+	ar.mx.Lock()
+	defer ar.mx.Unlock()
+
+	ar.speakers.Flush()
+
+	return ar.speakers.Turns()
+}
+
+// SaveAsWAV moves the finished recording to filePath
 func (ar *AudioCapturer) SaveAsWAV(filePath string) error {
-	return ar.wav.SaveAsWAV(filePath, ar.capturedData)
+	if ar.isCapturing.Load() {
+		return fmt.Errorf("capturing is in progress")
+	}
+	if ar.recordingDir == "" || ar.dataSize == 0 {
+		return fmt.Errorf("no audio data to save")
+	}
+
+	if err := ar.moveFile(mixFileName, filePath); err != nil {
+		return err
+	}
+
+	log.Printf("[I] Audio saved as WAV file: %s\n", filePath)
+	return nil
+}
+
+// HasTracks reports whether the mic and the system audio of the finished recording are kept as separate tracks
+func (ar *AudioCapturer) HasTracks() bool {
+	if ar.recordingDir == "" || ar.dataSize == 0 {
+		return false
+	}
+
+	_, err := os.Stat(filepath.Join(ar.recordingDir, micFileName))
+	return err == nil
+}
+
+// SaveTracksAsWAV moves the mic and the system audio tracks of the finished recording
+func (ar *AudioCapturer) SaveTracksAsWAV(micPath, systemPath string) error {
+	if ar.isCapturing.Load() {
+		return fmt.Errorf("capturing is in progress")
+	}
+	if !ar.HasTracks() {
+		return fmt.Errorf("no separate tracks captured")
+	}
+
+	if err := ar.moveFile(micFileName, micPath); err != nil {
+		return fmt.Errorf("failed to save mic track: %w", err)
+	}
+	if err := ar.moveFile(systemFileName, systemPath); err != nil {
+		return fmt.Errorf("failed to save system track: %w", err)
+	}
+
+	return nil
+}
+
+// openFiles starts a new recording directory, dropping the previous recording if it was not saved
+func (ar *AudioCapturer) openFiles() error {
+	if ar.recordingDir != "" {
+		os.RemoveAll(ar.recordingDir)
+		ar.recordingDir = ""
+	}
+
+	dir := filepath.Join(ar.dir, time.Now().Format("20060102_150405"))
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return fmt.Errorf("failed to create recording directory: %w", err)
+	}
+
+	captured, err := wav.NewStreamWriter(filepath.Join(dir, mixFileName), ar.sampleRate, ar.channels, ar.bitsPerSample)
+	if err != nil {
+		os.RemoveAll(dir)
+		return fmt.Errorf("failed to create recording file: %w", err)
+	}
+
+	ar.recordingDir = dir
+	ar.captured = captured
+	ar.dataSize = 0
+	ar.writeErr = nil
+
+	if !ar.separateTracks {
+		return nil
+	}
+
+	if ar.micTrack, err = wav.NewStreamWriter(filepath.Join(dir, micFileName), ar.sampleRate, ar.channels, ar.bitsPerSample); err != nil {
+		log.Printf("[I] Failed to create mic track, only the mix is recorded: %v\n", err)
+		return nil
+	}
+	if ar.systemTrack, err = wav.NewStreamWriter(filepath.Join(dir, systemFileName), ar.sampleRate, ar.channels, ar.bitsPerSample); err != nil {
+		log.Printf("[I] Failed to create system track, only the mix is recorded: %v\n", err)
+		ar.micTrack.Close()
+		ar.micTrack = nil
+		os.Remove(filepath.Join(dir, micFileName))
+	}
+
+	return nil
+}
+
+// closeFiles finalizes the headers of the recording files
+func (ar *AudioCapturer) closeFiles() error {
+	var errs []error
+	for _, sw := range []*wav.StreamWriter{ar.captured, ar.micTrack, ar.systemTrack} {
+		if sw == nil {
+			continue
+		}
+		if err := sw.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	ar.captured, ar.micTrack, ar.systemTrack = nil, nil, nil
+
+	return errors.Join(errs...)
+}
+
+// discardFiles closes and removes the files of a recording that failed to start
+func (ar *AudioCapturer) discardFiles() {
+	ar.closeFiles()
+	os.RemoveAll(ar.recordingDir)
+	ar.recordingDir = ""
+}
+
+// write appends data to a recording file, the first failure is logged and the capturing goes on
+func (ar *AudioCapturer) write(sw *wav.StreamWriter, data []byte) {
+	if _, err := sw.Write(data); err != nil && ar.writeErr == nil {
+		ar.writeErr = err
+		log.Printf("[I] Failed to write recording: %v\n", err)
+	}
+}
+
+// moveFile moves a file of the finished recording, the recording directory is removed once empty
+func (ar *AudioCapturer) moveFile(name, filePath string) error {
+	if err := os.Rename(filepath.Join(ar.recordingDir, name), filePath); err != nil {
+		return fmt.Errorf("failed to move recording: %w", err)
+	}
+
+	if err := os.Remove(ar.recordingDir); err == nil {
+		ar.recordingDir = ""
+	}
+
+	return nil
+}
+
+// padded returns buf padded with silence up to size bytes
+func padded(buf []byte, size int) []byte {
+	if len(buf) >= size {
+		return buf
+	}
+
+	out := make([]byte, size)
+	copy(out, buf)
+	return out
 }
 
 func mixInt16Stereo(a, b []byte) []byte {
@@ -0,0 +1,97 @@
+package audiocapture
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/mrbelka12000/interview_parser/internal/wav"
+)
+
+// RecoveredRecording is a recording left in the recording directory by a crash or an exit without saving
+type RecoveredRecording struct {
+	ID              string
+	Dir             string
+	FilePath        string
+	MicTrackPath    string
+	SystemTrackPath string
+}
+
+// RecoverRecordings repairs the WAV headers of the recordings left in dir.
+// Recordings without any audio are removed.
+func RecoverRecordings(dir string) ([]RecoveredRecording, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read recording directory: %w", err)
+	}
+
+	var recovered []RecoveredRecording
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		rec := RecoveredRecording{
+			ID:  entry.Name(),
+			Dir: filepath.Join(dir, entry.Name()),
+		}
+
+		rec.FilePath, err = repairFile(filepath.Join(rec.Dir, mixFileName))
+		if err != nil {
+			log.Printf("[I] Failed to recover recording %s: %v\n", rec.ID, err)
+			continue
+		}
+		if rec.FilePath == "" {
+			os.RemoveAll(rec.Dir)
+			continue
+		}
+
+		micPath, micErr := repairFile(filepath.Join(rec.Dir, micFileName))
+		systemPath, systemErr := repairFile(filepath.Join(rec.Dir, systemFileName))
+		if micErr == nil && systemErr == nil && micPath != "" && systemPath != "" {
+			rec.MicTrackPath, rec.SystemTrackPath = micPath, systemPath
+		}
+
+		recovered = append(recovered, rec)
+	}
+
+	return recovered, nil
+}
+
+// repairFile repairs the WAV file and returns its path, or an empty path when it is missing or has no audio
+func repairFile(filePath string) (string, error) {
+	stat, err := os.Stat(filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	if stat.Size() == 0 {
+		return "", nil
+	}
+
+	repaired, err := wav.Repair(filePath)
+	if err != nil {
+		return "", err
+	}
+	if repaired {
+		log.Printf("[I] Repaired WAV header of %s\n", filePath)
+	}
+
+	r, err := wav.OpenReader(filePath)
+	if err != nil {
+		return "", err
+	}
+	defer r.Close()
+
+	if r.DataSize == 0 {
+		return "", nil
+	}
+
+	return filePath, nil
+}
@@ -0,0 +1,80 @@
+package cli
+
+import (
+	"context"
+	"io"
+	"os"
+	"os/signal"
+)
+
+func (c *CLI) runAnalyzeInterview(args []string) error {
+	fs, format := c.newFlagSet("analyze-interview", "<media-file|transcript.txt>")
+	apiKey := fs.String("api-key", "", "OpenAI API key (defaults to OPENAI_API_KEY or the key saved in the app)")
+	output := fs.String("o", "", "write the result to this file instead of stdout")
+	langFlag := languageFlag(fs)
+	if err := c.parseFlags(fs, format, args, 1); err != nil {
+		return err
+	}
+	lang, err := c.parseLanguage(fs, *langFlag)
+	if err != nil {
+		return err
+	}
+
+	if err = c.initPipeline(*apiKey); err != nil {
+		return err
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	transcript, err := c.readOrTranscribe(ctx, fs.Arg(0), lang)
+	if err != nil {
+		return err
+	}
+
+	transcript.Text = c.parser.FormatText(transcript.Text)
+
+	interview, err := c.pipeline.AnalyzeInterview(ctx, transcript, lang)
+	if err = c.warnPartial(err); err != nil {
+		return err
+	}
+
+	return c.writeOutput(*output, *format, interview, func(w io.Writer) {
+		printInterview(w, *interview)
+	})
+}
+
+func (c *CLI) runAnalyzeCall(args []string) error {
+	fs, format := c.newFlagSet("analyze-call", "<media-file|transcript.txt>")
+	apiKey := fs.String("api-key", "", "OpenAI API key (defaults to OPENAI_API_KEY or the key saved in the app)")
+	output := fs.String("o", "", "write the result to this file instead of stdout")
+	langFlag := languageFlag(fs)
+	if err := c.parseFlags(fs, format, args, 1); err != nil {
+		return err
+	}
+	lang, err := c.parseLanguage(fs, *langFlag)
+	if err != nil {
+		return err
+	}
+
+	if err = c.initPipeline(*apiKey); err != nil {
+		return err
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	transcript, err := c.readOrTranscribe(ctx, fs.Arg(0), lang)
+	if err != nil {
+		return err
+	}
+
+	call, err := c.pipeline.AnalyzeCall(ctx, transcript, lang)
+	if err != nil {
+		return err
+	}
+
+	return c.writeOutput(*output, *format, call, func(w io.Writer) {
+		printCall(w, *call)
+	})
+}
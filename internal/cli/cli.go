@@ -0,0 +1,199 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/mrbelka12000/interview_parser/internal/client"
+	"github.com/mrbelka12000/interview_parser/internal/config"
+	"github.com/mrbelka12000/interview_parser/internal/models"
+	"github.com/mrbelka12000/interview_parser/internal/parser"
+	"github.com/mrbelka12000/interview_parser/internal/pipeline"
+	"github.com/mrbelka12000/interview_parser/internal/repo"
+	"github.com/mrbelka12000/interview_parser/internal/service"
+)
+
+const (
+	ExitOK      = 0
+	ExitFailure = 1
+	ExitUsage   = 2
+
+	formatText = "text"
+	formatJSON = "json"
+)
+
+var errUsage = errors.New("usage error")
+
+type (
+	// CLI drives the transcription and analysis pipeline from the command line
+	CLI struct {
+		cfg    *config.Config
+		stdout io.Writer
+		stderr io.Writer
+
+		service  *service.Service
+		parser   *parser.Parser
+		pipeline *pipeline.Pipeline
+	}
+
+	command struct {
+		name        string
+		description string
+		run         func(c *CLI, args []string) error
+	}
+)
+
+var commands = []command{
+	{name: "transcribe", description: "Transcribe a media file and print the transcript", run: (*CLI).runTranscribe},
+	{name: "analyze-interview", description: "Transcribe (or read) and analyze an interview, then save it", run: (*CLI).runAnalyzeInterview},
+	{name: "analyze-call", description: "Transcribe (or read) and analyze a call, then save it", run: (*CLI).runAnalyzeCall},
+	{name: "list", description: "List saved interviews or calls", run: (*CLI).runList},
+	{name: "export", description: "Export a saved interview or call", run: (*CLI).runExport},
+}
+
+// IsCommand reports whether name is a known CLI subcommand
+func IsCommand(name string) bool {
+	if name == "help" || name == "-h" || name == "--help" {
+		return true
+	}
+	for _, cmd := range commands {
+		if cmd.name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Run executes the subcommand in args and returns the process exit code
+func Run(cfg *config.Config, args []string) int {
+	c := &CLI{
+		cfg:    cfg,
+		stdout: os.Stdout,
+		stderr: os.Stderr,
+	}
+
+	if len(args) == 0 || args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		c.usage()
+		if len(args) == 0 {
+			return ExitUsage
+		}
+		return ExitOK
+	}
+
+	for _, cmd := range commands {
+		if cmd.name != args[0] {
+			continue
+		}
+
+		err := cmd.run(c, args[1:])
+		switch {
+		case err == nil:
+			return ExitOK
+		case errors.Is(err, errUsage), errors.Is(err, flag.ErrHelp):
+			return ExitUsage
+		default:
+			fmt.Fprintf(c.stderr, "Error: %v\n", err)
+			return ExitFailure
+		}
+	}
+
+	fmt.Fprintf(c.stderr, "Unknown command: %s\n\n", args[0])
+	c.usage()
+	return ExitUsage
+}
+
+func (c *CLI) usage() {
+	fmt.Fprintln(c.stderr, "Usage: interview_parser <command> [flags] [args]")
+	fmt.Fprintln(c.stderr)
+	fmt.Fprintln(c.stderr, "Commands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(c.stderr, "  %-18s %s\n", cmd.name, cmd.description)
+	}
+	fmt.Fprintln(c.stderr)
+	fmt.Fprintln(c.stderr, "Run 'interview_parser <command> -h' for command flags.")
+}
+
+// newFlagSet creates a flag set for the subcommand with the shared -format flag
+func (c *CLI) newFlagSet(name, argsUsage string) (*flag.FlagSet, *string) {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(c.stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(c.stderr, "Usage: interview_parser %s [flags] %s\n\nFlags:\n", name, argsUsage)
+		fs.PrintDefaults()
+	}
+
+	format := fs.String("format", formatText, "output format: text or json")
+	return fs, format
+}
+
+// parseFlags parses args and validates the output format and the number of positional arguments
+func (c *CLI) parseFlags(fs *flag.FlagSet, format *string, args []string, nArgs int) error {
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *format != formatText && *format != formatJSON {
+		fmt.Fprintf(c.stderr, "Invalid format: %s\n", *format)
+		fs.Usage()
+		return errUsage
+	}
+
+	if fs.NArg() != nArgs {
+		fs.Usage()
+		return errUsage
+	}
+
+	return nil
+}
+
+// languageFlag adds the -lang flag of the commands transcribing or analyzing a recording
+func languageFlag(fs *flag.FlagSet) *string {
+	return fs.String("lang", string(models.LanguageAuto), "language of the recording: ru, en or auto to use ANALYSIS_LANGUAGE or detect it")
+}
+
+// parseLanguage validates the -lang flag value
+func (c *CLI) parseLanguage(fs *flag.FlagSet, value string) (models.Language, error) {
+	lang, err := models.ParseLanguage(value)
+	if err != nil {
+		fmt.Fprintf(c.stderr, "Invalid language: %s\n", value)
+		fs.Usage()
+		return "", errUsage
+	}
+
+	return lang, nil
+}
+
+// initService opens the store selected by the config
+func (c *CLI) initService() {
+	if c.service != nil {
+		return
+	}
+
+	c.service = service.New(repo.NewRepositories(c.cfg))
+	c.parser = parser.NewParser(c.cfg)
+}
+
+// initPipeline prepares the AI client, using apiKey or the key stored in the database
+func (c *CLI) initPipeline(apiKey string) error {
+	c.initService()
+
+	if apiKey == "" {
+		apiKey = os.Getenv("OPENAI_API_KEY")
+	}
+	if apiKey == "" && client.RequiresAPIKey(c.cfg) {
+		stored, err := c.service.GetAPIKey()
+		if err != nil || stored == "" {
+			return errors.New("no API key provided: use -api-key, OPENAI_API_KEY or save one in the app")
+		}
+		apiKey = stored
+	}
+
+	c.pipeline = pipeline.New(c.cfg, c.parser, c.service, client.NewProvider(c.cfg, apiKey, c.service), func(_ uint64, percentage int, stage, details string) {
+		fmt.Fprintf(c.stderr, "[%3d%%] %s %s\n", percentage, stage, details)
+	})
+
+	return nil
+}
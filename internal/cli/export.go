@@ -0,0 +1,46 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+)
+
+func (c *CLI) runExport(args []string) error {
+	fs, format := c.newFlagSet("export", "interview|call <id>")
+	output := fs.String("o", "", "write the result to this file instead of stdout")
+	if err := c.parseFlags(fs, format, args, 2); err != nil {
+		return err
+	}
+
+	id, err := strconv.ParseUint(fs.Arg(1), 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid id: %s", fs.Arg(1))
+	}
+
+	c.initService()
+
+	switch fs.Arg(0) {
+	case "interview":
+		interview, err := c.service.GetInterview(id)
+		if err != nil {
+			return err
+		}
+
+		return c.writeOutput(*output, *format, interview, func(w io.Writer) {
+			printInterview(w, *interview)
+		})
+	case "call":
+		call, err := c.service.GetCall(id)
+		if err != nil {
+			return err
+		}
+
+		return c.writeOutput(*output, *format, call, func(w io.Writer) {
+			printCall(w, *call)
+		})
+	default:
+		fs.Usage()
+		return errUsage
+	}
+}
@@ -0,0 +1,95 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/mrbelka12000/interview_parser/internal/models"
+)
+
+func (c *CLI) runList(args []string) error {
+	fs, format := c.newFlagSet("list", "interviews|calls")
+	dateFrom := fs.String("from", "", "only include records created on or after this date (YYYY-MM-DD)")
+	dateTo := fs.String("to", "", "only include records created on or before this date (YYYY-MM-DD)")
+	limit := fs.Int("limit", 0, "maximum number of calls to list (0 means no limit)")
+	offset := fs.Int("offset", 0, "number of calls to skip")
+	if err := c.parseFlags(fs, format, args, 1); err != nil {
+		return err
+	}
+
+	filters, err := parseDateFilters(*dateFrom, *dateTo)
+	if err != nil {
+		return err
+	}
+
+	c.initService()
+
+	switch fs.Arg(0) {
+	case "interviews":
+		interviews, err := c.service.GetAllInterviews(filters)
+		if err != nil {
+			return err
+		}
+		if interviews == nil {
+			interviews = []models.AnalyzeInterviewWithQA{}
+		}
+
+		return c.writeOutput("", *format, interviews, func(w io.Writer) {
+			for _, interview := range interviews {
+				fmt.Fprintf(w, "%d\t%s\t%d questions\n", interview.ID, interview.CreatedAt.Format(time.DateTime), len(interview.QA))
+			}
+		})
+	case "calls":
+		var calls []models.Call
+		if filters.DateFrom != nil || filters.DateTo != nil {
+			from, to := time.Time{}, time.Now()
+			if filters.DateFrom != nil {
+				from = *filters.DateFrom
+			}
+			if filters.DateTo != nil {
+				to = *filters.DateTo
+			}
+			calls, err = c.service.GetCallsByDateRange(from, to)
+		} else {
+			calls, err = c.service.GetAllCalls(*limit, *offset)
+		}
+		if err != nil {
+			return err
+		}
+		if calls == nil {
+			calls = []models.Call{}
+		}
+
+		return c.writeOutput("", *format, calls, func(w io.Writer) {
+			for _, call := range calls {
+				fmt.Fprintf(w, "%d\t%s\t%s\n", call.ID, call.CreatedAt.Format(time.DateTime), preview(call.Transcript, 60))
+			}
+		})
+	default:
+		fs.Usage()
+		return errUsage
+	}
+}
+
+func parseDateFilters(dateFrom, dateTo string) (*models.GetInterviewsFilters, error) {
+	filters := &models.GetInterviewsFilters{}
+
+	if dateFrom != "" {
+		parsed, err := time.Parse("2006-01-02", dateFrom)
+		if err != nil {
+			return nil, fmt.Errorf("invalid -from format: %w", err)
+		}
+		filters.DateFrom = &parsed
+	}
+
+	if dateTo != "" {
+		parsed, err := time.Parse("2006-01-02", dateTo)
+		if err != nil {
+			return nil, fmt.Errorf("invalid -to format: %w", err)
+		}
+		filters.DateTo = &parsed
+	}
+
+	return filters, nil
+}
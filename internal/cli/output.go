@@ -0,0 +1,103 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/mrbelka12000/interview_parser/internal/models"
+)
+
+// writeOutput prints v as JSON or through printText, to stdout or to outputFile when set
+func (c *CLI) writeOutput(outputFile, format string, v interface{}, printText func(w io.Writer)) error {
+	w := c.stdout
+	if outputFile != "" {
+		f, err := os.Create(outputFile)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer f.Close()
+		w = f
+	}
+
+	if format == formatJSON {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(v); err != nil {
+			return fmt.Errorf("failed to encode output: %w", err)
+		}
+		return nil
+	}
+
+	printText(w)
+	return nil
+}
+
+func printInterview(w io.Writer, interview models.AnalyzeInterviewWithQA) {
+	fmt.Fprintf(w, "Interview #%d (%s)\n\n", interview.ID, interview.CreatedAt.Format(time.DateTime))
+
+	for i, qa := range interview.QA {
+		fmt.Fprintf(w, "%d. Q%s: %s\n", i+1, speakerSuffix(qa.Questioner), qa.Question)
+		if qa.StartTime != nil && qa.EndTime != nil {
+			fmt.Fprintf(w, "   Time: %s - %s\n", formatTimestamp(*qa.StartTime), formatTimestamp(*qa.EndTime))
+		}
+		fmt.Fprintf(w, "   A%s: %s\n", speakerSuffix(qa.Answerer), qa.FullAnswer)
+		fmt.Fprintf(w, "   Accuracy: %.2f\n", qa.Accuracy)
+		if qa.ReasonUnanswered != "" {
+			fmt.Fprintf(w, "   Reason: %s\n", qa.ReasonUnanswered)
+		}
+		fmt.Fprintln(w)
+	}
+}
+
+func printCall(w io.Writer, call models.Call) {
+	fmt.Fprintf(w, "Call #%d (%s)\n\n", call.ID, call.CreatedAt.Format(time.DateTime))
+
+	if len(call.Analysis) > 0 {
+		var analysis bytes.Buffer
+		if err := json.Indent(&analysis, call.Analysis, "", "  "); err != nil {
+			analysis.Reset()
+			analysis.Write(call.Analysis)
+		}
+		fmt.Fprintf(w, "Analysis:\n%s\n\n", analysis.String())
+	}
+
+	fmt.Fprintf(w, "Transcript:\n%s\n", call.Transcript)
+}
+
+func printSegments(w io.Writer, segments models.TranscriptSegments) {
+	for _, seg := range segments {
+		text := strings.TrimSpace(seg.Text)
+		if seg.Speaker != "" {
+			text = seg.Speaker + ": " + text
+		}
+		fmt.Fprintf(w, "[%s - %s] %s\n", formatTimestamp(seg.Start), formatTimestamp(seg.End), text)
+	}
+}
+
+// speakerSuffix formats the speaker name as " (name)", or nothing when it is unknown
+func speakerSuffix(speaker string) string {
+	if speaker == "" {
+		return ""
+	}
+	return " (" + speaker + ")"
+}
+
+// formatTimestamp formats seconds as hh:mm:ss
+func formatTimestamp(seconds float64) string {
+	d := time.Duration(seconds) * time.Second
+	return fmt.Sprintf("%02d:%02d:%02d", int(d.Hours()), int(d.Minutes())%60, int(d.Seconds())%60)
+}
+
+func preview(text string, n int) string {
+	text = strings.Join(strings.Fields(text), " ")
+	runes := []rune(text)
+	if len(runes) <= n {
+		return text
+	}
+	return string(runes[:n]) + "..."
+}
@@ -0,0 +1,114 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+
+	"github.com/mrbelka12000/interview_parser/internal/models"
+	"github.com/mrbelka12000/interview_parser/internal/pipeline"
+)
+
+type transcribeOutput struct {
+	File       string                    `json:"file"`
+	Transcript string                    `json:"transcript"`
+	Segments   models.TranscriptSegments `json:"segments,omitempty"`
+}
+
+func (c *CLI) runTranscribe(args []string) error {
+	fs, format := c.newFlagSet("transcribe", "<media-file>")
+	apiKey := fs.String("api-key", "", "OpenAI API key (defaults to OPENAI_API_KEY or the key saved in the app)")
+	output := fs.String("o", "", "write the result to this file instead of stdout")
+	raw := fs.Bool("raw", false, "do not split the transcript into question/answer paragraphs")
+	timestamps := fs.Bool("timestamps", false, "print timed segments instead of the text (text format only)")
+	langFlag := languageFlag(fs)
+	if err := c.parseFlags(fs, format, args, 1); err != nil {
+		return err
+	}
+	lang, err := c.parseLanguage(fs, *langFlag)
+	if err != nil {
+		return err
+	}
+
+	if err = c.initPipeline(*apiKey); err != nil {
+		return err
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	transcript, err := c.transcribe(ctx, fs.Arg(0), lang)
+	if err != nil {
+		return err
+	}
+	if !*raw {
+		transcript.Text = c.parser.FormatText(transcript.Text)
+	}
+
+	out := transcribeOutput{
+		File:       fs.Arg(0),
+		Transcript: transcript.Text,
+		Segments:   transcript.Segments,
+	}
+
+	return c.writeOutput(*output, *format, out, func(w io.Writer) {
+		if *timestamps {
+			printSegments(w, transcript.Segments)
+			return
+		}
+		fmt.Fprint(w, transcript.Text)
+	})
+}
+
+// transcribe runs the media file through the chunking and transcription pipeline, lang is the spoken language hint
+func (c *CLI) transcribe(ctx context.Context, filePath string, lang models.Language) (*models.Transcript, error) {
+	if _, err := os.Stat(filePath); err != nil {
+		return nil, fmt.Errorf("file is not accessible: %w", err)
+	}
+
+	transcript, err := c.pipeline.TranscribeFile(ctx, filePath, lang)
+	if err = c.warnPartial(err); err != nil {
+		return nil, err
+	}
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	if strings.TrimSpace(transcript.Text) == "" {
+		return nil, errors.New("transcription produced no text")
+	}
+
+	return transcript, nil
+}
+
+// readOrTranscribe reads .txt transcripts as is (without timings) and transcribes any other media file
+func (c *CLI) readOrTranscribe(ctx context.Context, filePath string, lang models.Language) (*models.Transcript, error) {
+	if strings.ToLower(filepath.Ext(filePath)) != ".txt" {
+		return c.transcribe(ctx, filePath, lang)
+	}
+
+	body, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read transcript: %w", err)
+	}
+	if strings.TrimSpace(string(body)) == "" {
+		return nil, errors.New("transcript file is empty")
+	}
+
+	return &models.Transcript{Text: string(body)}, nil
+}
+
+// warnPartial prints the chunks and batches that failed when the rest of the result is usable and drops the error
+func (c *CLI) warnPartial(err error) error {
+	var partial *pipeline.PartialError
+	if !errors.As(err, &partial) {
+		return err
+	}
+
+	fmt.Fprintf(c.stderr, "Warning: %v\n", partial)
+	return nil
+}
@@ -20,7 +20,7 @@ type (
 	Question struct {
 		Question         string  `json:"question"`
 		FullAnswer       string  `json:"full_answer"`
-		Accuracy         float64 `json:"accuracy"`
+		Accuracy         float64 `json:"accuracy" jsonschema:"minimum=0,maximum=1"`
 		Questioner       string  `json:"questioner"`
 		Answerer         string  `json:"answerer"`
 		ReasonUnanswered string  `json:"reason"`
@@ -34,67 +34,77 @@ type (
 		Meta           string
 		Questions      []string
 		Answers        []string
+		// Language of the evaluation, auto detects it from the questions
+		Language models.Language
 	}
 
 	AnalyzeMockInterviewResponse struct {
-		CandidateSummary    string `json:"candidate_summary"`
-		EvaluationLevel     string `json:"evaluation_level"`
-		QuestionsEvaluation []struct {
-			Question         string  `json:"question"`
-			Answer           string  `json:"answer"`
-			Accuracy         float64 `json:"accuracy"`
-			Assessment       string  `json:"assessment"`
-			ReasonUnanswered string  `json:"reason_unanswered"`
-			WhatWasExpected  string  `json:"what_was_expected"`
-		} `json:"questions_evaluation"`
-		FinalScore struct {
-			AverageAccuracy float64 `json:"average_accuracy"`
-			Verdict         string  `json:"verdict"`
-			VerdictReason   string  `json:"verdict_reason"`
-		} `json:"final_score"`
+		CandidateSummary    string               `json:"candidate_summary"`
+		EvaluationLevel     string               `json:"evaluation_level"`
+		QuestionsEvaluation []QuestionEvaluation `json:"questions_evaluation"`
+		FinalScore          FinalScore           `json:"final_score"`
+	}
+	QuestionEvaluation struct {
+		Question         string  `json:"question"`
+		Answer           string  `json:"answer"`
+		Accuracy         float64 `json:"accuracy" jsonschema:"minimum=0,maximum=1"`
+		Assessment       string  `json:"assessment"`
+		ReasonUnanswered string  `json:"reason_unanswered"`
+		WhatWasExpected  string  `json:"what_was_expected"`
+	}
+	FinalScore struct {
+		AverageAccuracy float64 `json:"average_accuracy" jsonschema:"minimum=0,maximum=1"`
+		Verdict         string  `json:"verdict"`
+		VerdictReason   string  `json:"verdict_reason"`
 	}
 
 	CallResponse struct {
-		MeetingAnalysis struct {
-			KeyTopics []string `json:"key_topics"`
-			Tasks     []struct {
-				Title    string  `json:"title"`
-				Assignee string  `json:"assignee"`
-				Deadline *string `json:"deadline"`
-			} `json:"tasks"`
-			OpenQuestionsAndBlockers []string `json:"open_questions_and_blockers"`
-			NextSteps                []string `json:"next_steps"`
-		} `json:"meeting_analysis"`
+		MeetingAnalysis MeetingAnalysis `json:"meeting_analysis"`
+	}
+	MeetingAnalysis struct {
+		KeyTopics                []string      `json:"key_topics"`
+		Tasks                    []MeetingTask `json:"tasks"`
+		OpenQuestionsAndBlockers []string      `json:"open_questions_and_blockers"`
+		NextSteps                []string      `json:"next_steps"`
+	}
+	MeetingTask struct {
+		Title    string  `json:"title"`
+		Assignee string  `json:"assignee"`
+		Deadline *string `json:"deadline"`
 	}
 )
 
-func (c *Client) AnalyzeTranscript(ctx context.Context, text string) (out models.AnalyzeInterviewWithQA, err error) {
+func (c *Client) AnalyzeTranscript(ctx context.Context, text string, lang models.Language, opts AnalysisOptions) (out models.AnalyzeInterviewWithQA, err error) {
 	now := time.Now()
 	log.Printf("[i] Analyzing transcript")
 
-	var resp AnalyzeResponse
+	model := c.analysisModel(opts)
+	tpl := c.analysisPrompt(opts, PromptAnalyzeInterview, c.cfg.ResolveLanguage(lang, text))
+	key := analysisKey(OpAnalyzeTranscript, model, tpl, text)
 
-	res, err := c.cl.Chat.Completions.New(ctx, openai.ChatCompletionNewParams{
-		Messages: []openai.ChatCompletionMessageParamUnion{
-			openai.SystemMessage(promptAnalyze),
-			openai.UserMessage(fmt.Sprintf(transcriptHeader, text)),
-		},
-		Model: c.cfg.GPTClassifyQuestionsModel,
-	})
-	if err != nil {
-		return out, fmt.Errorf("failed to analyze transcript: %w", err)
-	}
-
-	if err = json.Unmarshal([]byte(res.Choices[0].Message.Content), &resp); err != nil {
-		return out, fmt.Errorf("failed to unmarshal response: %w", err)
+	var resp AnalyzeResponse
+	if !opts.SkipCache && c.cache.get(key, &resp) {
+		log.Printf("[i] Using the cached analysis of the transcript")
+	} else {
+		err = chatJSON(ctx, c, c.classifyCl, OpAnalyzeTranscript, openai.ChatCompletionNewParams{
+			Messages: transcriptMessages(tpl, text),
+			Model:    model,
+		}, &resp)
+		if err != nil {
+			return out, fmt.Errorf("failed to analyze transcript: %w", err)
+		}
+		c.cache.put(key, resp)
 	}
 
+	out.PromptVersion = tpl.Ref()
 	for _, q := range resp.Questions {
 		out.QA = append(out.QA, models.QuestionAnswer{
 			Question:         q.Question,
 			FullAnswer:       q.FullAnswer,
 			Accuracy:         q.Accuracy,
 			ReasonUnanswered: q.ReasonUnanswered,
+			Questioner:       q.Questioner,
+			Answerer:         q.Answerer,
 		})
 	}
 
@@ -102,24 +112,26 @@ func (c *Client) AnalyzeTranscript(ctx context.Context, text string) (out models
 	return out, nil
 }
 
-func (c *Client) AnalyzeCall(ctx context.Context, transcript string) (out *models.Call, err error) {
+func (c *Client) AnalyzeCall(ctx context.Context, transcript string, lang models.Language) (out *models.Call, err error) {
 	now := time.Now()
 	log.Printf("[i] Analyzing call transcript")
 
-	res, err := c.cl.Chat.Completions.New(ctx, openai.ChatCompletionNewParams{
-		Messages: []openai.ChatCompletionMessageParamUnion{
-			openai.SystemMessage(promptCallAnalyze),
-			openai.UserMessage(transcript),
-		},
-		Model: c.cfg.GPTClassifyQuestionsModel,
-	})
-	if err != nil {
-		return out, fmt.Errorf("failed to analyze call: %w", err)
-	}
+	model := c.cfg.GPTClassifyQuestionsModel
+	tpl := c.prompt(PromptAnalyzeCall, c.cfg.ResolveLanguage(lang, transcript))
+	key := analysisKey(OpAnalyzeCall, model, tpl, transcript)
 
 	var resp CallResponse
-	if err = json.Unmarshal([]byte(res.Choices[0].Message.Content), &resp); err != nil {
-		return out, fmt.Errorf("failed to unmarshal response: %w", err)
+	if c.cache.get(key, &resp) {
+		log.Printf("[i] Using the cached analysis of the call")
+	} else {
+		err = chatJSON(ctx, c, c.classifyCl, OpAnalyzeCall, openai.ChatCompletionNewParams{
+			Messages: transcriptMessages(tpl, transcript),
+			Model:    model,
+		}, &resp)
+		if err != nil {
+			return out, fmt.Errorf("failed to analyze call: %w", err)
+		}
+		c.cache.put(key, resp)
 	}
 
 	log.Printf("[i] Finished analyzing call, seconds spent: %v", time.Since(now).Seconds())
@@ -130,8 +142,9 @@ func (c *Client) AnalyzeCall(ctx context.Context, transcript string) (out *model
 	}
 
 	return &models.Call{
-		Transcript: transcript,
-		Analysis:   body,
+		Transcript:    transcript,
+		Analysis:      body,
+		PromptVersion: tpl.Ref(),
 	}, nil
 }
 
@@ -140,22 +153,40 @@ func (c *Client) AnalyzeMockInterview(ctx context.Context, req AnalyzeMockInterv
 	now := time.Now()
 	log.Printf("[i] Analyzing mock interview")
 
-	res, err := c.cl.Chat.Completions.New(ctx, openai.ChatCompletionNewParams{
-		Messages: []openai.ChatCompletionMessageParamUnion{
-			openai.SystemMessage(fmt.Sprintf(promptAnalyzeMockInterview,
-				req.CV, req.VacancyInfo, req.Specialization, req.Level,
-				req.Meta, strings.Join(req.Questions, "\n"), strings.Join(req.Answers, "\n"))),
-		},
-		Model: c.cfg.GPTClassifyQuestionsModel,
+	questions := strings.Join(req.Questions, "\n")
+	tpl := c.prompt(PromptAnalyzeMockInterview, c.cfg.ResolveLanguage(req.Language, questions))
+	system := renderPrompt(tpl.Body, map[string]string{
+		"cv":             req.CV,
+		"vacancy":        req.VacancyInfo,
+		"specialization": req.Specialization,
+		"level":          req.Level,
+		"meta":           req.Meta,
+		"questions":      questions,
+		"answers":        strings.Join(req.Answers, "\n"),
 	})
-	if err != nil {
-		return out, fmt.Errorf("failed to analyze mock interview: %w", err)
+	// the rendered prompt holds every input of the analysis
+	key := analysisKey(OpAnalyzeMockInterview, c.cfg.GPTClassifyQuestionsModel, tpl, system)
+
+	if c.cache.get(key, &out) {
+		log.Printf("[i] Using the cached analysis of the mock interview")
+		return out, nil
 	}
 
-	if err = json.Unmarshal([]byte(res.Choices[0].Message.Content), &out); err != nil {
-		return out, fmt.Errorf("failed to unmarshal response: %w", err)
+	err = chatJSON(ctx, c, c.classifyCl, OpAnalyzeMockInterview, openai.ChatCompletionNewParams{
+		Messages: []openai.ChatCompletionMessageParamUnion{openai.SystemMessage(system)},
+		Model:    c.cfg.GPTClassifyQuestionsModel,
+	}, &out)
+	if err != nil {
+		return out, fmt.Errorf("failed to analyze mock interview: %w", err)
 	}
+	c.cache.put(key, out)
 
-	log.Printf("[i] Finished analyzing mock interview, seconds spent: %v", time.Since(now).Seconds())
+	log.Printf("[i] Finished analyzing mock interview with prompt %s, seconds spent: %v", tpl.Ref(), time.Since(now).Seconds())
 	return out, nil
 }
+
+// analysisKey identifies the analysis of the text by the model with the prompt template. The body of the template
+// is part of the key, so a changed built-in prompt is not answered with the results of the old one.
+func analysisKey(op, model string, tpl *models.PromptTemplate, text string) string {
+	return cacheKey(op, model, tpl.Ref(), tpl.Body, text)
+}
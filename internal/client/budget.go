@@ -0,0 +1,110 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+
+	"github.com/openai/openai-go"
+
+	"github.com/mrbelka12000/interview_parser/internal/config"
+)
+
+// charsPerToken is about how many characters of English text make a token
+const charsPerToken = 4
+
+// ErrBudgetExceeded is returned for requests and jobs that would take the spending over its limits
+var ErrBudgetExceeded = errors.New("spending limit reached")
+
+// budgetReservations are the estimated costs of the requests in flight, whose usage is not recorded yet.
+// Parallel requests count them, so they cannot all pass the spending limits before any of them is recorded.
+type budgetReservations struct {
+	mx    sync.Mutex
+	month float64
+	jobs  map[uint64]float64
+}
+
+// CheckBudget fails with ErrBudgetExceeded when spending estimate USD more would take the month or the job over
+// their spending limits. The job limit applies only when jobID is set.
+func CheckBudget(cfg *config.LimitsConfig, usage UsageRecorder, jobID uint64, estimate float64) error {
+	return checkSpending(cfg, usage, jobID, estimate, 0, 0)
+}
+
+// checkSpending is CheckBudget with the month and the job spending increased by the reserved amounts
+func checkSpending(cfg *config.LimitsConfig, usage UsageRecorder, jobID uint64, estimate, reservedMonth, reservedJob float64) error {
+	if usage == nil || (cfg.SpendingMonthlyLimit <= 0 && cfg.SpendingJobLimit <= 0) {
+		return nil
+	}
+
+	month, job, err := usage.GetSpending(jobID)
+	if err != nil {
+		// the spending is unknown the same way when recording the usage fails, neither stops the work
+		log.Printf("[I] Failed to check spending: %v\n", err)
+		return nil
+	}
+	month += reservedMonth
+	job += reservedJob
+
+	if jobID != 0 && cfg.SpendingJobLimit > 0 && job+estimate > cfg.SpendingJobLimit {
+		return fmt.Errorf("%w: job %d would cost $%.2f, over its $%.2f limit", ErrBudgetExceeded, jobID, job+estimate, cfg.SpendingJobLimit)
+	}
+	if cfg.SpendingMonthlyLimit > 0 && month+estimate > cfg.SpendingMonthlyLimit {
+		return fmt.Errorf("%w: spending this month would be $%.2f, over the $%.2f limit", ErrBudgetExceeded, month+estimate, cfg.SpendingMonthlyLimit)
+	}
+
+	return nil
+}
+
+// reserveBudget checks that a request of the model is within the spending limits, estimating its cost
+// from the audio duration or the prompt tokens, and reserves the estimate for the request.
+// The returned release must be called once the request is done and its usage, if any, is recorded.
+func (c *Client) reserveBudget(ctx context.Context, op, model string, audioSeconds float64, promptTokens int64) (release func(), err error) {
+	if c.usage == nil || (c.cfg.SpendingMonthlyLimit <= 0 && c.cfg.SpendingJobLimit <= 0) {
+		return func() {}, nil
+	}
+
+	var (
+		estimate = c.cfg.Cost(model, audioSeconds, promptTokens, 0)
+		jobID    = JobFromContext(ctx)
+		b        = &c.budget
+	)
+
+	b.mx.Lock()
+	defer b.mx.Unlock()
+
+	if err := checkSpending(&c.cfg.LimitsConfig, c.usage, jobID, estimate, b.month, b.jobs[jobID]); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if b.jobs == nil {
+		b.jobs = make(map[uint64]float64)
+	}
+	b.month += estimate
+	b.jobs[jobID] += estimate
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			b.mx.Lock()
+			defer b.mx.Unlock()
+
+			b.month -= estimate
+			if b.jobs[jobID] -= estimate; b.jobs[jobID] <= 0 {
+				delete(b.jobs, jobID)
+			}
+		})
+	}, nil
+}
+
+// estimatePromptTokens estimates the tokens of the request messages
+func estimatePromptTokens(params openai.ChatCompletionNewParams) int64 {
+	body, err := json.Marshal(params.Messages)
+	if err != nil {
+		return 0
+	}
+
+	return textTokens(string(body))
+}
@@ -0,0 +1,210 @@
+package client
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mrbelka12000/interview_parser/internal/config"
+)
+
+type (
+	// responseCache keeps the results of requests in dir by the hash of their input, so the same chunk or
+	// transcript is paid for once. Over maxSize bytes the least recently used results are removed.
+	responseCache struct {
+		dir     string
+		maxSize int64
+
+		mx sync.Mutex
+		// size is the size of dir as far as the cache knows, -1 until it is measured
+		size int64
+	}
+
+	// cacheEntry is a cached result file
+	cacheEntry struct {
+		path    string
+		size    int64
+		modTime time.Time
+	}
+)
+
+// newResponseCache returns the cache configured in cfg, nil when it is turned off.
+// Recording and replaying turn it off too: every request has to reach the recorder.
+func newResponseCache(cfg *config.GPTConfig) *responseCache {
+	if cfg.GPTCacheMaxSizeMB <= 0 || cfg.GPTCacheDir == "" {
+		return nil
+	}
+	if cfg.GPTRecordMode == config.RecordModeRecord || cfg.GPTRecordMode == config.RecordModeReplay {
+		return nil
+	}
+
+	return &responseCache{
+		dir:     cfg.GPTCacheDir,
+		maxSize: cfg.GPTCacheMaxSizeMB << 20,
+		size:    -1,
+	}
+}
+
+// get decodes the cached result of the key into out and reports whether there was one
+func (c *responseCache) get(key string, out any) bool {
+	if c == nil {
+		return false
+	}
+
+	path := c.path(key)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		log.Printf("[I] Failed to read cached response %s: %v\n", key, err)
+		return false
+	}
+
+	// the modification time orders the entries for eviction
+	now := time.Now()
+	_ = os.Chtimes(path, now, now)
+
+	return true
+}
+
+// put caches the result of the key and removes the least recently used results when the cache grows too big.
+// Failures are only logged, the result was already paid for.
+func (c *responseCache) put(key string, v any) {
+	if c == nil {
+		return
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		log.Printf("[I] Failed to cache response %s: %v\n", key, err)
+		return
+	}
+	if int64(len(data)) > c.maxSize {
+		return
+	}
+
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	// a result written again replaces the old one, whose size no longer counts
+	path := c.path(key)
+	var oldSize int64
+	if info, err := os.Stat(path); err == nil {
+		oldSize = info.Size()
+	}
+
+	if err := writeFileAtomic(path, data); err != nil {
+		log.Printf("[I] Failed to cache response %s: %v\n", key, err)
+		return
+	}
+
+	if c.size >= 0 {
+		c.size += int64(len(data)) - oldSize
+	}
+	if c.size < 0 || c.size > c.maxSize {
+		c.evict()
+	}
+}
+
+// evict measures the cache and removes the least recently used results until it fits maxSize
+func (c *responseCache) evict() {
+	var (
+		entries []cacheEntry
+		size    int64
+	)
+	err := filepath.WalkDir(c.dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !strings.HasSuffix(path, ".json") {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+
+		entries = append(entries, cacheEntry{path: path, size: info.Size(), modTime: info.ModTime()})
+		size += info.Size()
+		return nil
+	})
+	if err != nil {
+		log.Printf("[I] Failed to measure the response cache: %v\n", err)
+		return
+	}
+
+	if size > c.maxSize {
+		slices.SortFunc(entries, func(a, b cacheEntry) int {
+			return a.modTime.Compare(b.modTime)
+		})
+
+		var removed int
+		for _, entry := range entries {
+			if size <= c.maxSize {
+				break
+			}
+			if err := os.Remove(entry.path); err != nil && !os.IsNotExist(err) {
+				continue
+			}
+			size -= entry.size
+			removed++
+		}
+		log.Printf("[i] Removed %d least recently used responses from the cache", removed)
+	}
+
+	c.size = size
+}
+
+// path returns the file of the key, spread over subdirectories by its first byte
+func (c *responseCache) path(key string) string {
+	return filepath.Join(c.dir, key[:2], key+".json")
+}
+
+// cacheKey hashes the parts identifying the input of a request
+func cacheKey(parts ...string) string {
+	h := sha256.New()
+	for _, part := range parts {
+		io.WriteString(h, part)
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// fileHash returns the SHA-256 of the content read from r
+func fileHash(r io.Reader) (string, error) {
+	h := sha256.New()
+	if _, err := io.Copy(h, r); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// writeFileAtomic writes the file through a temporary one, so a concurrent reader never sees half of it
+func writeFileAtomic(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,48 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/mrbelka12000/interview_parser/internal/config"
+)
+
+func TestNewResponseCache(t *testing.T) {
+	tests := []struct {
+		name    string
+		mode    string
+		maxSize int64
+		want    bool
+	}{
+		{name: "on", mode: config.RecordModeOff, maxSize: 512, want: true},
+		{name: "turned off by size", mode: config.RecordModeOff, maxSize: 0},
+		{name: "off while recording", mode: config.RecordModeRecord, maxSize: 512},
+		{name: "off while replaying", mode: config.RecordModeReplay, maxSize: 512},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cache := newResponseCache(&config.GPTConfig{
+				GPTRecordMode:     tt.mode,
+				GPTCacheDir:       t.TempDir(),
+				GPTCacheMaxSizeMB: tt.maxSize,
+			})
+			if got := cache != nil; got != tt.want {
+				t.Errorf("cache turned on = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResponseCacheRoundTrip(t *testing.T) {
+	cache := newResponseCache(&config.GPTConfig{GPTCacheDir: t.TempDir(), GPTCacheMaxSizeMB: 1})
+
+	var out string
+	if cache.get("key", &out) {
+		t.Fatal("empty cache returned a result")
+	}
+
+	cache.put("key", "result")
+	if !cache.get("key", &out) || out != "result" {
+		t.Errorf("get() = %q, want the put result", out)
+	}
+}
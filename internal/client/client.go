@@ -1,6 +1,8 @@
 package client
 
 import (
+	"log"
+
 	"github.com/openai/openai-go"
 	"github.com/openai/openai-go/option"
 
@@ -8,15 +10,70 @@ import (
 )
 
 type Client struct {
-	cl  openai.Client
-	cfg config.Config
+	transcribeCl openai.Client
+	classifyCl   openai.Client
+	generateCl   openai.Client
+	cfg          config.Config
+	usage        UsageRecorder
+	prompts      PromptStore
+	cache        *responseCache
+	budget       budgetReservations
+}
+
+// New creates a client for the OpenAI-compatible endpoints of cfg. The usage of every request is recorded in store
+// and checked against the spending limits before it is sent, the prompts edited by the user are loaded from it.
+// In replay mode the requests are answered from the recorded responses, they cost nothing and are not recorded.
+// Transcription and analysis results are cached by their input unless the cache is turned off.
+func New(cfg *config.Config, apiKey string, store Store) *Client {
+	rec := newRecorder(&cfg.GPTConfig)
+
+	c := &Client{
+		transcribeCl: newOpenAIClient(apiKey, cfg.TranscribeEndpoint(), rec),
+		classifyCl:   newOpenAIClient(apiKey, cfg.ClassifyQuestionsEndpoint(), rec),
+		generateCl:   newOpenAIClient(apiKey, cfg.GenerateQuestionsEndpoint(), rec),
+		cfg:          *cfg,
+		usage:        store,
+		prompts:      store,
+		cache:        newResponseCache(&cfg.GPTConfig),
+	}
+	if cfg.Replays() {
+		c.usage = nil
+	}
+	if model := cfg.ChunkTranscribeModel(); !config.SupportsSegments(model) {
+		log.Printf("[I] Transcription model %s does not return segment timestamps, questions are timed by whole chunks. "+
+			"Set GPT_SEGMENTS_MODEL to a whisper model to get them\n", model)
+	}
+
+	return c
 }
 
-func New(cfg *config.Config, apiKey string) *Client {
-	return &Client{
-		cl: openai.NewClient(
-			option.WithAPIKey(apiKey),
-		),
-		cfg: *cfg,
+// newOpenAIClient creates a client for an OpenAI-compatible endpoint, its responses are recorded or replayed by rec
+// unless it is nil. A key configured on the endpoint takes precedence over apiKey.
+func newOpenAIClient(apiKey string, endpoint config.GPTEndpoint, rec *recorder) openai.Client {
+	if endpoint.APIKey != "" {
+		apiKey = endpoint.APIKey
 	}
+
+	opts := []option.RequestOption{
+		option.WithAPIKey(apiKey),
+		// requests are retried by the client itself, with a longer backoff
+		option.WithMaxRetries(0),
+	}
+	if endpoint.BaseURL != "" {
+		opts = append(opts, option.WithBaseURL(endpoint.BaseURL))
+	}
+	if endpoint.Organization != "" {
+		opts = append(opts, option.WithOrganization(endpoint.Organization))
+	}
+	if endpoint.Project != "" {
+		opts = append(opts, option.WithProject(endpoint.Project))
+	}
+	for key, value := range endpoint.Headers {
+		opts = append(opts, option.WithHeader(key, value))
+	}
+	if rec != nil {
+		opts = append(opts, option.WithMiddleware(rec.middleware))
+	}
+
+	return openai.NewClient(opts...)
 }
@@ -0,0 +1,88 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/openai/openai-go"
+
+	"github.com/mrbelka12000/interview_parser/internal/models"
+)
+
+type (
+	diarizeRequest struct {
+		Segments []diarizeRequestSegment `json:"segments"`
+	}
+	diarizeRequestSegment struct {
+		ID   int    `json:"id"`
+		Text string `json:"text"`
+	}
+
+	diarizeResponse struct {
+		Segments []diarizeResponseSegment `json:"segments"`
+	}
+	diarizeResponseSegment struct {
+		ID    int           `json:"id"`
+		Turns []diarizeTurn `json:"turns"`
+	}
+	diarizeTurn struct {
+		Speaker string `json:"speaker"`
+		Text    string `json:"text"`
+	}
+)
+
+// Diarize asks the chat model to split every segment into speaker turns
+func (c *Client) Diarize(ctx context.Context, segments models.TranscriptSegments, speakers []string) ([]models.TranscriptSegments, error) {
+	now := time.Now()
+	log.Printf("[i] Diarizing %d transcript segments", len(segments))
+
+	req := diarizeRequest{Segments: make([]diarizeRequestSegment, len(segments))}
+	for i, seg := range segments {
+		req.Segments[i] = diarizeRequestSegment{ID: i, Text: seg.Text}
+	}
+
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal segments: %w", err)
+	}
+
+	hint := diarizeUnknownSpeakersHint
+	if len(speakers) > 0 {
+		hint = fmt.Sprintf(diarizeSpeakersHint, strings.Join(speakers, ", "))
+	}
+
+	var resp diarizeResponse
+	err = chatJSON(ctx, c, c.classifyCl, OpDiarize, openai.ChatCompletionNewParams{
+		Messages: []openai.ChatCompletionMessageParamUnion{
+			openai.SystemMessage(fmt.Sprintf(promptDiarize, hint)),
+			openai.UserMessage(string(body)),
+		},
+		Model: c.cfg.GPTDiarizeModel,
+	}, &resp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to diarize transcript: %w", err)
+	}
+
+	out := make([]models.TranscriptSegments, len(segments))
+	for _, seg := range resp.Segments {
+		if seg.ID < 0 || seg.ID >= len(segments) {
+			continue
+		}
+		for _, turn := range seg.Turns {
+			if strings.TrimSpace(turn.Text) == "" {
+				continue
+			}
+			out[seg.ID] = append(out[seg.ID], models.TranscriptSegment{
+				Text:    turn.Text,
+				Speaker: strings.TrimSpace(turn.Speaker),
+			})
+		}
+	}
+
+	log.Printf("[i] Finished diarizing transcript, seconds spent: %v", time.Since(now).Seconds())
+	return out, nil
+}
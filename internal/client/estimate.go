@@ -0,0 +1,48 @@
+package client
+
+import (
+	"github.com/mrbelka12000/interview_parser/internal/config"
+	"github.com/mrbelka12000/interview_parser/internal/models"
+)
+
+const (
+	// speechTokensPerMinute is about how many tokens the transcript of a minute of speech has
+	speechTokensPerMinute = 200
+	// analysisBatchTokens is about how many transcript tokens are analyzed in one request
+	analysisBatchTokens = 1000
+	// diarizeBatchTokens is about how many transcript tokens are diarized in one request
+	diarizeBatchTokens = 1500
+)
+
+// EstimateCost estimates what processing audioSeconds of a recording as the job type costs with the configured models.
+// The transcript length is guessed from the usual pace of speech, the analysis output from the transcript length.
+func EstimateCost(cfg *config.Config, jobType models.JobType, audioSeconds float64) models.CostEstimate {
+	estimate := models.CostEstimate{AudioSeconds: audioSeconds}
+	if cfg.AIProvider == config.AIProviderFake || audioSeconds <= 0 {
+		return estimate
+	}
+
+	transcript := int64(audioSeconds / 60 * speechTokensPerMinute)
+	estimate.Transcribe = cfg.Cost(cfg.ChunkTranscribeModel(), audioSeconds, 0, transcript)
+
+	if cfg.Diarize {
+		batches := transcript/diarizeBatchTokens + 1
+		estimate.Diarize = cfg.Cost(cfg.GPTDiarizeModel, 0, transcript+batches*textTokens(promptDiarize), transcript)
+	}
+
+	switch jobType {
+	case models.JobTypeCall:
+		estimate.Analyze = cfg.Cost(cfg.GPTClassifyQuestionsModel, 0, transcript+textTokens(promptCallAnalyze), transcript/2)
+	default:
+		batches := transcript/analysisBatchTokens + 1
+		estimate.Analyze = cfg.Cost(cfg.GPTClassifyQuestionsModel, 0, transcript+batches*textTokens(promptAnalyze), transcript)
+	}
+
+	estimate.Total = estimate.Transcribe + estimate.Diarize + estimate.Analyze
+	return estimate
+}
+
+// textTokens estimates the tokens of the text
+func textTokens(text string) int64 {
+	return int64(len(text) / charsPerToken)
+}
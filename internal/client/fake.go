@@ -0,0 +1,295 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/mrbelka12000/interview_parser/internal/models"
+)
+
+// FakeClient is a deterministic offline Provider. It returns canned transcripts
+// and derives analyses from the input text, so the whole pipeline can run without network.
+type FakeClient struct{}
+
+var (
+	fakeDialogue = []struct {
+		category string
+		question string
+		answer   string
+	}{
+		{"experience", "Tell me about your current project?", "I work on a payment platform written in Go with a PostgreSQL database and Kafka for events."},
+		{"go", "How do goroutines differ from operating system threads?", "Goroutines are scheduled by the Go runtime on a small pool of threads and start with a tiny growable stack."},
+		{"databases", "What isolation levels do you know?", "Read uncommitted, read committed, repeatable read and serializable."},
+		{"architecture", "How would you make a service idempotent?", "I would store an idempotency key with the result and return the stored result on retries."},
+		{"behavioral", "How do you handle disagreements in code review?", "I do not know."},
+		{"testing", "How do you test code that calls external APIs?", "I hide the client behind an interface and use a fake in unit tests, plus a few contract tests."},
+	}
+
+	fakeChunkIndex = regexp.MustCompile(`_chunk_(\d+)`)
+)
+
+// fakeSecondsPerWord is the speaking rate used to time the canned segments
+const fakeSecondsPerWord = 0.4
+
+func NewFake() *FakeClient {
+	return &FakeClient{}
+}
+
+// IsValidAPIKeysProvided always succeeds, the fake backend does not need a key
+func (f *FakeClient) IsValidAPIKeysProvided() error {
+	return nil
+}
+
+// Transcribe returns a canned question and answer chosen by the chunk index in the file name.
+// The question and the answer are separate segments, timed by their word count. The transcript is always in English.
+func (f *FakeClient) Transcribe(ctx context.Context, chunkPath string, _ float64, _ models.Language) (out models.Transcript, err error) {
+	if err := ctx.Err(); err != nil {
+		return out, err
+	}
+	if _, err := os.Stat(chunkPath); err != nil {
+		return out, fmt.Errorf("%v file open error: %w", chunkPath, err)
+	}
+
+	line := fakeDialogue[fakeIndex(chunkPath)%len(fakeDialogue)]
+
+	var start float64
+	for _, text := range []string{line.question, line.answer} {
+		end := start + math.Round(float64(len(strings.Fields(text)))*fakeSecondsPerWord*100)/100
+		out.Segments = append(out.Segments, models.TranscriptSegment{
+			Start: start,
+			End:   end,
+			Text:  text,
+		})
+		start = end
+	}
+	out.Text = line.question + " " + line.answer + " "
+
+	return out, nil
+}
+
+// Diarize gives questions to the first speaker and everything else to the second one
+func (f *FakeClient) Diarize(ctx context.Context, segments models.TranscriptSegments, speakers []string) ([]models.TranscriptSegments, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(speakers) < 2 {
+		speakers = []string{"Speaker 1", "Speaker 2"}
+	}
+
+	out := make([]models.TranscriptSegments, len(segments))
+	for i, seg := range segments {
+		for _, pair := range splitQuestions(seg.Text) {
+			out[i] = append(out[i], models.TranscriptSegment{Text: pair[0], Speaker: speakers[0]})
+			if pair[1] != "" {
+				out[i] = append(out[i], models.TranscriptSegment{Text: pair[1], Speaker: speakers[1]})
+			}
+		}
+		if len(out[i]) == 0 {
+			out[i] = models.TranscriptSegments{{Text: seg.Text, Speaker: speakers[1]}}
+		}
+	}
+
+	return out, nil
+}
+
+// AnalyzeTranscript treats every sentence ending with "?" as a question and the text after it as the answer.
+// The prompt of the options is reported as the prompt version, nothing is cached.
+func (f *FakeClient) AnalyzeTranscript(ctx context.Context, text string, _ models.Language, opts AnalysisOptions) (out models.AnalyzeInterviewWithQA, err error) {
+	if err := ctx.Err(); err != nil {
+		return out, err
+	}
+	if opts.Prompt != nil {
+		out.PromptVersion = opts.Prompt.Ref()
+	}
+
+	for _, pair := range splitQuestions(text) {
+		accuracy, reason := fakeAccuracy(pair[1])
+		out.QA = append(out.QA, models.QuestionAnswer{
+			Question:         pair[0],
+			FullAnswer:       pair[1],
+			Accuracy:         accuracy,
+			ReasonUnanswered: reason,
+			Questioner:       "interviewer",
+			Answerer:         "candidate",
+		})
+	}
+
+	return out, nil
+}
+
+// AnalyzeCall uses the questions of the transcript as topics and open questions
+func (f *FakeClient) AnalyzeCall(ctx context.Context, transcript string, _ models.Language) (*models.Call, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	analysis := MeetingAnalysis{
+		KeyTopics:                []string{},
+		Tasks:                    []MeetingTask{},
+		OpenQuestionsAndBlockers: []string{},
+		NextSteps:                []string{"Share meeting notes with the team"},
+	}
+
+	for _, pair := range splitQuestions(transcript) {
+		if len(analysis.KeyTopics) < 3 {
+			analysis.KeyTopics = append(analysis.KeyTopics, pair[0])
+		}
+		if _, reason := fakeAccuracy(pair[1]); reason != "" {
+			analysis.OpenQuestionsAndBlockers = append(analysis.OpenQuestionsAndBlockers, pair[0])
+			analysis.Tasks = append(analysis.Tasks, MeetingTask{Title: "Follow up: " + pair[0]})
+		}
+	}
+
+	body, err := json.Marshal(analysis)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal response: %w", err)
+	}
+
+	return &models.Call{
+		Transcript: transcript,
+		Analysis:   body,
+	}, nil
+}
+
+// GetMockInterviewQuestions returns the canned questions in a fixed order
+func (f *FakeClient) GetMockInterviewQuestions(ctx context.Context, req MockInterviewRequest) (out MockInterviewResponse, err error) {
+	if err := ctx.Err(); err != nil {
+		return out, err
+	}
+
+	count := req.QuestionsCount
+	if count <= 0 {
+		count = 5
+	}
+
+	out.VacancySummary = fmt.Sprintf("%s %s position", req.Level, req.Specialization)
+	for i := 0; i < count; i++ {
+		line := fakeDialogue[i%len(fakeDialogue)]
+		out.GeneratedQuestions = append(out.GeneratedQuestions, GeneratedQuestion{
+			Category: line.category,
+			Question: line.question,
+			WhyAsked: "Canned question of the offline provider",
+		})
+	}
+
+	return out, nil
+}
+
+// AnalyzeMockInterview scores each answer by its length
+func (f *FakeClient) AnalyzeMockInterview(ctx context.Context, req AnalyzeMockInterviewRequest) (out AnalyzeMockInterviewResponse, err error) {
+	if err := ctx.Err(); err != nil {
+		return out, err
+	}
+
+	out.CandidateSummary = fmt.Sprintf("Candidate answered %d questions", len(req.Answers))
+	out.EvaluationLevel = req.Level
+
+	var total float64
+	for i, question := range req.Questions {
+		var answer string
+		if i < len(req.Answers) {
+			answer = req.Answers[i]
+		}
+
+		accuracy, reason := fakeAccuracy(answer)
+		total += accuracy
+		out.QuestionsEvaluation = append(out.QuestionsEvaluation, QuestionEvaluation{
+			Question:         question,
+			Answer:           answer,
+			Accuracy:         accuracy,
+			Assessment:       "Scored by answer length",
+			ReasonUnanswered: reason,
+		})
+	}
+
+	if len(req.Questions) > 0 {
+		out.FinalScore.AverageAccuracy = math.Round(total/float64(len(req.Questions))*100) / 100
+	}
+
+	switch avg := out.FinalScore.AverageAccuracy; {
+	case avg >= 0.85:
+		out.FinalScore.Verdict = "Strong Match"
+	case avg >= 0.7:
+		out.FinalScore.Verdict = "Match"
+	case avg >= 0.5:
+		out.FinalScore.Verdict = "Weak Match"
+	default:
+		out.FinalScore.Verdict = "No Match"
+	}
+	out.FinalScore.VerdictReason = fmt.Sprintf("Average accuracy is %.2f", out.FinalScore.AverageAccuracy)
+
+	return out, nil
+}
+
+// fakeIndex extracts the chunk number from the file name, falling back to a hash of the name
+func fakeIndex(chunkPath string) int {
+	name := filepath.Base(chunkPath)
+	if m := fakeChunkIndex.FindStringSubmatch(name); m != nil {
+		if idx, err := strconv.Atoi(m[1]); err == nil {
+			return idx
+		}
+	}
+
+	h := fnv.New32a()
+	h.Write([]byte(name))
+	return int(h.Sum32() % math.MaxInt32)
+}
+
+// fakeAccuracy gives longer answers a higher score, "I do not know" style answers score zero
+func fakeAccuracy(answer string) (float64, string) {
+	words := len(strings.Fields(answer))
+	switch {
+	case words == 0:
+		return 0, "No answer given"
+	case words < 6:
+		return 0.2, "Answer is too short"
+	}
+
+	return math.Min(1, math.Round((0.6+float64(words)/50)*100)/100), ""
+}
+
+// splitQuestions returns [question, answer] pairs, the answer being the text up to the next question
+func splitQuestions(text string) [][2]string {
+	var (
+		pairs    [][2]string
+		sentence strings.Builder
+		answer   strings.Builder
+	)
+
+	flush := func() {
+		if len(pairs) > 0 {
+			pairs[len(pairs)-1][1] = strings.TrimSpace(answer.String())
+		}
+		answer.Reset()
+	}
+
+	for _, r := range text {
+		sentence.WriteRune(r)
+
+		switch r {
+		case '?':
+			// the current sentence is a question, the text before it belongs to the previous answer
+			question := strings.TrimSpace(sentence.String())
+			flush()
+			pairs = append(pairs, [2]string{question, ""})
+			sentence.Reset()
+		case '.', '!', '\n':
+			answer.WriteString(sentence.String())
+			sentence.Reset()
+		}
+	}
+
+	answer.WriteString(sentence.String())
+	flush()
+
+	return pairs
+}
@@ -0,0 +1,127 @@
+package client
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/mrbelka12000/interview_parser/internal/config"
+)
+
+// rateWindow is the period the rate limits are counted over
+const rateWindow = time.Minute
+
+type (
+	// rateLimiter holds the requests to a model back while the requests or tokens sent in the last minute
+	// would exceed its limits
+	rateLimiter struct {
+		mx    sync.Mutex
+		limit config.RateLimit
+		sent  []*sentRequest
+	}
+
+	// sentRequest is a request counted by the limiter, its tokens are estimated until the response reports them
+	sentRequest struct {
+		at     time.Time
+		tokens int64
+	}
+)
+
+var (
+	// limiters are shared by all clients, a client is created again whenever the API key changes
+	limitersMx sync.Mutex
+	limiters   = make(map[string]*rateLimiter)
+)
+
+// limiterFor returns the limiter of the model, nil when the model is not limited
+func limiterFor(cfg *config.LimitsConfig, model string) *rateLimiter {
+	limit := cfg.RateLimit(model)
+	if limit.Unlimited() {
+		return nil
+	}
+
+	limitersMx.Lock()
+	defer limitersMx.Unlock()
+
+	l, ok := limiters[model]
+	if !ok {
+		l = &rateLimiter{}
+		limiters[model] = l
+	}
+	l.mx.Lock()
+	l.limit = limit
+	l.mx.Unlock()
+
+	return l
+}
+
+// wait blocks until the request with the estimated tokens fits the limits and counts it as sent.
+// A request larger than the token limit is sent alone once the last minute is clear.
+func (l *rateLimiter) wait(ctx context.Context, op string, tokens int64) (*sentRequest, error) {
+	for logged := false; ; logged = true {
+		delay, req := l.reserve(tokens)
+		if req != nil {
+			return req, nil
+		}
+
+		if !logged {
+			log.Printf("[i] %s waits %v for the rate limit", op, delay.Round(time.Millisecond))
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+}
+
+// reserve counts the request as sent when it fits the limits, otherwise returns how long until the oldest request
+// leaves the window
+func (l *rateLimiter) reserve(tokens int64) (time.Duration, *sentRequest) {
+	l.mx.Lock()
+	defer l.mx.Unlock()
+
+	now := time.Now()
+	for len(l.sent) > 0 && now.Sub(l.sent[0].at) >= rateWindow {
+		l.sent = l.sent[1:]
+	}
+
+	var used int64
+	for _, req := range l.sent {
+		used += req.tokens
+	}
+
+	fits := (l.limit.Requests <= 0 || len(l.sent) < l.limit.Requests) &&
+		(l.limit.Tokens <= 0 || used+tokens <= int64(l.limit.Tokens) || len(l.sent) == 0)
+	if !fits {
+		return l.sent[0].at.Add(rateWindow).Sub(now), nil
+	}
+
+	req := &sentRequest{at: now, tokens: tokens}
+	l.sent = append(l.sent, req)
+	return 0, req
+}
+
+// settle replaces the estimated tokens of the request with the ones it used
+func (l *rateLimiter) settle(req *sentRequest, tokens int64) {
+	l.mx.Lock()
+	req.tokens = tokens
+	l.mx.Unlock()
+}
+
+// throttle waits until a request of op to the model fits the rate limits.
+// The returned function records the tokens the request used, it is a no-op for unlimited models and replayed requests.
+func (c *Client) throttle(ctx context.Context, op, model string, tokens int64) (func(used int64), error) {
+	l := limiterFor(&c.cfg.LimitsConfig, model)
+	if l == nil || c.cfg.Replays() {
+		return func(int64) {}, nil
+	}
+
+	req, err := l.wait(ctx, op, tokens)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(used int64) { l.settle(req, used) }, nil
+}
@@ -2,12 +2,14 @@ package client
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"log"
+	"strconv"
 	"time"
 
 	"github.com/openai/openai-go"
+
+	"github.com/mrbelka12000/interview_parser/internal/models"
 )
 
 type (
@@ -18,6 +20,8 @@ type (
 		Level          string
 		Meta           string
 		QuestionsCount int
+		// Language of the questions, auto detects it from the CV and the vacancy
+		Language models.Language
 	}
 
 	MockInterviewResponse struct {
@@ -31,25 +35,40 @@ type (
 	}
 )
 
+// GetMockInterviewQuestions generates the questions of a mock interview. Unlike the analyses it is not cached:
+// generating again for the same CV and vacancy is how the user asks for other questions.
 func (c *Client) GetMockInterviewQuestions(ctx context.Context, req MockInterviewRequest) (out MockInterviewResponse, err error) {
 	now := time.Now()
 	log.Printf("[i] Generating mock interview")
 
-	res, err := c.cl.Chat.Completions.New(ctx, openai.ChatCompletionNewParams{
+	tpl := c.prompt(PromptGenerateMockInterview, c.cfg.ResolveLanguage(req.Language, req.CV+"\n"+req.VacancyInfo))
+
+	err = chatJSON(ctx, c, c.generateCl, OpGenerateMockInterview, openai.ChatCompletionNewParams{
 		Messages: []openai.ChatCompletionMessageParamUnion{
-			openai.SystemMessage(fmt.Sprintf(promptGenerateMockInterview, req.CV, req.VacancyInfo, req.Specialization, req.Level, req.Meta, req.QuestionsCount)),
+			openai.SystemMessage(renderPrompt(tpl.Body, map[string]string{
+				"cv":              req.CV,
+				"vacancy":         req.VacancyInfo,
+				"specialization":  req.Specialization,
+				"level":           req.Level,
+				"meta":            req.Meta,
+				"questions_count": strconv.Itoa(req.QuestionsCount),
+			})),
 		},
 		Model: c.cfg.GPTGenerateQuestionsModel,
-	})
+	}, &out)
 	if err != nil {
 		return out, fmt.Errorf("failed to generate mock interview: %w", err)
 	}
 
-	if err = json.Unmarshal([]byte(res.Choices[0].Message.Content), &out); err != nil {
-		return out, fmt.Errorf("failed to unmarshal response: %w", err)
-	}
-
-	log.Printf("[i] Successfully generated mock interview, spent: %v", time.Since(now).Seconds())
+	log.Printf("[i] Successfully generated mock interview with prompt %s, spent: %v", tpl.Ref(), time.Since(now).Seconds())
 
 	return out, nil
 }
+
+// Validate makes sure questions were generated
+func (r *MockInterviewResponse) Validate() error {
+	if len(r.GeneratedQuestions) == 0 {
+		return fmt.Errorf("generated_questions is empty")
+	}
+	return nil
+}
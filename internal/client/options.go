@@ -0,0 +1,31 @@
+package client
+
+import (
+	"github.com/mrbelka12000/interview_parser/internal/models"
+)
+
+// AnalysisOptions override the configured model and prompt of a transcript analysis
+type AnalysisOptions struct {
+	// Model replaces the configured analysis model when set
+	Model string
+	// Prompt replaces the latest template of its name and language when set
+	Prompt *models.PromptTemplate
+	// SkipCache asks the model again even when the same analysis is cached, e.g. for an explicit re-analysis
+	SkipCache bool
+}
+
+// analysisModel returns the model the transcript analyses with the options are made with
+func (c *Client) analysisModel(opts AnalysisOptions) string {
+	if opts.Model != "" {
+		return opts.Model
+	}
+	return c.cfg.GPTClassifyQuestionsModel
+}
+
+// analysisPrompt returns the template of the name in the language the analyses with the options are made with
+func (c *Client) analysisPrompt(opts AnalysisOptions, name string, lang models.Language) *models.PromptTemplate {
+	if tpl := opts.Prompt; tpl != nil && tpl.Name == name && tpl.Language == lang {
+		return tpl
+	}
+	return c.prompt(name, lang)
+}
@@ -1,5 +1,7 @@
 package client
 
+import "github.com/mrbelka12000/interview_parser/internal/models"
+
 var (
 	promptCallAnalyze = `
 Ты анализируешь транскрипт или краткое содержание ежедневной встречи (daily).
@@ -54,6 +56,59 @@ var (
 	5.	Никакого текста до или после JSON.
 `
 
+	promptCallAnalyzeEN = `
+You are analyzing a transcript or a summary of a daily meeting.
+
+GOAL:
+Extract the essence of the discussion and turn it into a clear, practical action plan.
+
+INPUT:
+I will provide the text of the meeting (a transcript or a summary).
+
+OUTPUT:
+Return the result STRICTLY as valid JSON.
+No text, comments or explanations outside of the JSON.
+
+OUTPUT FORMAT (required):
+{
+  "meeting_analysis": {
+    "key_topics": [
+      "Topic 1",
+      "Topic 2",
+      "Topic 3"
+    ],
+    "tasks": [
+      {
+        "title": "Task 1",
+        "assignee": "Name or null",
+        "deadline": "YYYY-MM-DD or null"
+      },
+      {
+        "title": "Task 2",
+        "assignee": "Name or null",
+        "deadline": null
+      }
+    ],
+    "open_questions_and_blockers": [
+      "Question 1",
+      "Blocker 1"
+    ],
+    "next_steps": [
+      "Step 1",
+      "Step 2",
+      "Step 3"
+    ]
+  }
+}
+
+RULES:
+	1.	Use only the listed fields.
+	2.	If there is no information, use null or an empty array.
+	3.	The JSON must be valid (no trailing commas).
+	4.	Be brief, specific and action-oriented.
+	5.	No text before or after the JSON.
+`
+
 	promptAnalyze = `
 Ты — модель o3, анализирующая транскрипт собеседования (интервьюер ↔ кандидат).
 
@@ -270,7 +325,7 @@ var (
                      ТРАНСКРИПТ ДЛЯ АНАЛИЗА
 ----------------------------------------------------------------------
 
-	%v
+	{{transcript}}
 `
 	promptAnalyzeEN = `
 You are analyzing an interview transcript (interviewer ↔ candidate).
@@ -356,7 +411,7 @@ OUTPUT FORMAT — strictly ONLY valid JSON according to the structure at the end
 					TRANSCRIPT FOR ANALYSIS
 ----------------------------------------------------------------------
 
-	%v
+	{{transcript}}
 `
 
 	promptGenerateMockInterview = `
@@ -368,22 +423,22 @@ OUTPUT FORMAT — strictly ONLY valid JSON according to the structure at the end
                            ВХОДНЫЕ ДАННЫЕ
 ----------------------------------------------------------------------
 1) Резюме кандидата (полный текст):
-%v
+{{cv}}
 
 2) Информация о вакансии (полный текст JD):
-%v
+{{vacancy}}
 
 3) Специальность кандидата (Go Developer, QA, Designer и т.п.):
-%v
+{{specialization}}
 
 4) Уровень позиции (Junior, Middle, Senior, Team Lead, Tech Lead):
-%v
+{{level}}
 
 5) Дополнительные сведения (могут быть неструктурированные):
-%v
+{{meta}}
 
 6) Требуемое количество вопросов:
-%v
+{{questions_count}}
 
 ----------------------------------------------------------------------
                           ЖЁСТКИЕ ПРАВИЛА
@@ -420,7 +475,71 @@ OUTPUT FORMAT — strictly ONLY valid JSON according to the structure at the end
   ]
 }
 
-Количество элементов в generated_questions = {{QUESTIONS_COUNT}}.
+Количество элементов в generated_questions = {{questions_count}}.
+`
+
+	promptGenerateMockInterviewEN = `
+You are a professional technical interviewer with deep experience interviewing IT specialists of all levels.
+
+Your task: based on the input data, prepare a set of relevant, high-quality and diverse questions for a mock interview.
+
+----------------------------------------------------------------------
+                              INPUT DATA
+----------------------------------------------------------------------
+1) Candidate CV (full text):
+{{cv}}
+
+2) Vacancy information (full text of the JD):
+{{vacancy}}
+
+3) Candidate specialization (Go Developer, QA, Designer, etc.):
+{{specialization}}
+
+4) Position level (Junior, Middle, Senior, Team Lead, Tech Lead):
+{{level}}
+
+5) Additional information (may be unstructured):
+{{meta}}
+
+6) Required number of questions:
+{{questions_count}}
+
+----------------------------------------------------------------------
+                              HARD RULES
+----------------------------------------------------------------------
+1. Take ALL of the input data into account at once — do not ignore anything.
+2. Questions must match the position level and the real experience of the candidate.
+3. Write the questions in the style of a *real company interview*:
+   - technical questions
+   - architecture (for Middle+ levels)
+   - behavioral questions (soft skills)
+   - questions about the project experience from the CV
+   - questions about the technology stack from the JD
+4. Every question must be:
+   - clear
+   - checking a specific skill
+   - tailored personally to the CV
+5. Do not make the questions harder than the position level.
+6. Use only relevant technologies.
+7. The output format is strictly JSON.
+
+----------------------------------------------------------------------
+                            OUTPUT STRUCTURE
+----------------------------------------------------------------------
+Output JSON of the following form:
+
+{
+  "vacancy_summary": "<main requirements of the vacancy>",
+  "generated_questions": [
+     {
+        "category": "tech | architecture | soft | past_experience | debugging | algorithms",
+        "question": "<the question>",
+        "why_asked": "<which skill or competence it checks>"
+     }
+  ]
+}
+
+The number of elements in generated_questions = {{questions_count}}.
 `
 
 	promptAnalyzeMockInterview = `
@@ -432,25 +551,25 @@ OUTPUT FORMAT — strictly ONLY valid JSON according to the structure at the end
                                ВХОДНЫЕ ДАННЫЕ
 ----------------------------------------------------------------------
 1) Резюме кандидата:
-%v
+{{cv}}
 
 2) Информация о вакансии:
-%v
+{{vacancy}}
 
 3) Специальность кандидата:
-%v
+{{specialization}}
 
 4) Уровень позиции (Junior / Middle / Senior / TL / Tech Lead):
-%v
+{{level}}
 
 6) Дополнительные сведения (неструктурированные, но значимые):
-%v
+{{meta}}
 
 7) Список вопросов:
-%v
+{{questions}}
 
 8) Ответы кандидата (по порядку):
-%v
+{{answers}}
 
 ----------------------------------------------------------------------
                            ЖЁСТКИЕ ПРАВИЛА ОЦЕНКИ
@@ -492,7 +611,7 @@ OUTPUT FORMAT — strictly ONLY valid JSON according to the structure at the end
 ----------------------------------------------------------------------
 {
   "candidate_summary": "<краткий анализ кандидата>",
-  "evaluation_level": "{{LEVEL}}",
+  "evaluation_level": "{{level}}",
   "questions_evaluation": [
     {
       "question": "<оригинальный вопрос>",
@@ -510,22 +629,171 @@ OUTPUT FORMAT — strictly ONLY valid JSON according to the structure at the end
   }
 }
 `
+
+	promptAnalyzeMockInterviewEN = `
+You are a professional technical interviewer with wide experience in Go, Backend, QA, Design
+and other IT specializations. Your task: strictly and objectively evaluate the answers of the candidate
+to the mock interview questions, taking all of the provided parameters into account.
+
+----------------------------------------------------------------------
+                               INPUT DATA
+----------------------------------------------------------------------
+1) Candidate CV:
+{{cv}}
+
+2) Vacancy information:
+{{vacancy}}
+
+3) Candidate specialization:
+{{specialization}}
+
+4) Position level (Junior / Middle / Senior / TL / Tech Lead):
+{{level}}
+
+5) Additional information (unstructured, but significant):
+{{meta}}
+
+6) List of questions:
+{{questions}}
+
+7) Candidate answers (in order):
+{{answers}}
+
+----------------------------------------------------------------------
+                          HARD EVALUATION RULES
+----------------------------------------------------------------------
+1. Take ALL parameters of the candidate and the vacancy into account.
+2. The evaluation of an answer depends on:
+   - the position level (Junior ≠ Senior)
+   - the expectations of the vacancy
+   - the CV (if the candidate claims experience, they must be able to explain it)
+   - the depth of the explanation
+   - the correctness of the facts
+   - the ability to structure thoughts
+   - the relevance to the question
+
+3. For every question determine:
+   ✓ How complete the answer is
+   ✓ How technically correct the answer is
+   ✓ Whether the answer matches the position level
+   ✓ Whether key details were missed
+   ✓ Whether the candidate gave examples from experience
+   ✓ Whether it matches what is stated in the CV
+
+4. Give a score:
+   - **accuracy** — a number from 0.0 to 1.0
+     1.0 = perfect answer
+     0.7–0.89 = good, but with gaps
+     0.4–0.69 = partial answer
+     0.1–0.39 = weak answer
+     0.0 = off-topic / no answer
+
+5. For weak or incomplete answers always give:
+   - reason_unanswered: why the answer is weak
+   - what_was_expected: what the candidate should have said
+
+6. The output is strictly JSON. No text outside of the JSON.
+
+----------------------------------------------------------------------
+                              OUTPUT FORMAT
+----------------------------------------------------------------------
+{
+  "candidate_summary": "<short analysis of the candidate>",
+  "evaluation_level": "{{level}}",
+  "questions_evaluation": [
+    {
+      "question": "<original question>",
+      "answer": "<candidate answer>",
+      "accuracy": 0.0,
+      "assessment": "<detailed expert assessment of the answer>",
+      "reason_unanswered": "<if accuracy < 0.7>",
+      "what_was_expected": "<if accuracy < 0.7>"
+    }
+  ],
+  "final_score": {
+      "average_accuracy": 0.0,
+      "verdict": "<Strong Match | Match | Weak Match | No Match>",
+      "verdict_reason": "<why this is the final verdict>"
+  }
+}
+`
+
+	promptDiarize = `
+You split a transcript of a conversation into speaker turns.
+
+INPUT:
+A JSON object with transcript segments in chronological order:
+{"segments": [{"id": 0, "text": "..."}]}
+A segment may contain speech of several speakers.
+
+TASK:
+1. Split every segment into consecutive turns of one speaker.
+2. Label every turn with the speaker name.
+%s
+3. Keep the text of the turns exactly as in the segment, in the original language: do not translate, fix or shorten it.
+   The turns of a segment joined together must give the segment text.
+4. Use the same name for the same person in all segments.
+
+OUTPUT:
+Return STRICTLY valid JSON, without any text before or after it:
+{
+  "segments": [
+    {
+      "id": 0,
+      "turns": [
+        {"speaker": "name", "text": "..."}
+      ]
+    }
+  ]
+}
+`
+
+	diarizeSpeakersHint = `   Use only these speaker names: %s.`
+
+	diarizeUnknownSpeakersHint = `   Use the names of the people if they are mentioned in the conversation, otherwise "Speaker 1", "Speaker 2" and so on.`
 )
 
-func getAnalyzePrompt(lang string) string {
+func getAnalyzePrompt(lang models.Language) string {
 	switch lang {
-	case "en":
+	case models.LanguageEN:
 		return promptAnalyzeEN
 	default:
 		return promptAnalyze
 	}
 }
 
-func getTranscriptPrompt(lang string) string {
+func getTranscriptPrompt(lang models.Language) string {
 	switch lang {
-	case "en":
+	case models.LanguageEN:
 		return transcriptHeaderEN
 	default:
 		return transcriptHeader
 	}
 }
+
+func getCallAnalyzePrompt(lang models.Language) string {
+	switch lang {
+	case models.LanguageEN:
+		return promptCallAnalyzeEN
+	default:
+		return promptCallAnalyze
+	}
+}
+
+func getGenerateMockInterviewPrompt(lang models.Language) string {
+	switch lang {
+	case models.LanguageEN:
+		return promptGenerateMockInterviewEN
+	default:
+		return promptGenerateMockInterview
+	}
+}
+
+func getAnalyzeMockInterviewPrompt(lang models.Language) string {
+	switch lang {
+	case models.LanguageEN:
+		return promptAnalyzeMockInterviewEN
+	default:
+		return promptAnalyzeMockInterview
+	}
+}
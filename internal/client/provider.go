@@ -0,0 +1,81 @@
+package client
+
+import (
+	"context"
+
+	"github.com/mrbelka12000/interview_parser/internal/config"
+	"github.com/mrbelka12000/interview_parser/internal/models"
+)
+
+type (
+	// Transcriber converts an audio chunk into text.
+	// Segment times are relative to the beginning of the chunk. duration is the length of the chunk in seconds,
+	// the cost of the request is estimated from it. lang is a hint of the spoken language, auto leaves it to the backend.
+	Transcriber interface {
+		Transcribe(ctx context.Context, chunkPath string, duration float64, lang models.Language) (models.Transcript, error)
+	}
+
+	// Diarizer splits transcript segments into speaker turns. speakers are the expected names,
+	// when empty the backend names the speakers itself. The result has an entry per segment
+	// with the turns text and speaker, an empty entry means the segment was not labelled.
+	Diarizer interface {
+		Diarize(ctx context.Context, segments models.TranscriptSegments, speakers []string) ([]models.TranscriptSegments, error)
+	}
+
+	// InterviewAnalyzer extracts question/answer pairs from an interview transcript in the language,
+	// auto detects it from the text. opts override the configured model and prompt.
+	InterviewAnalyzer interface {
+		AnalyzeTranscript(ctx context.Context, text string, lang models.Language, opts AnalysisOptions) (models.AnalyzeInterviewWithQA, error)
+	}
+
+	// CallAnalyzer extracts topics, tasks and next steps from a meeting transcript in the language,
+	// auto detects it from the text
+	CallAnalyzer interface {
+		AnalyzeCall(ctx context.Context, transcript string, lang models.Language) (*models.Call, error)
+	}
+
+	// QuestionGenerator generates mock interview questions and evaluates the answers
+	QuestionGenerator interface {
+		GetMockInterviewQuestions(ctx context.Context, req MockInterviewRequest) (MockInterviewResponse, error)
+		AnalyzeMockInterview(ctx context.Context, req AnalyzeMockInterviewRequest) (AnalyzeMockInterviewResponse, error)
+	}
+
+	// Provider is a backend implementing every AI task used by the app
+	Provider interface {
+		Transcriber
+		Diarizer
+		InterviewAnalyzer
+		CallAnalyzer
+		QuestionGenerator
+
+		IsValidAPIKeysProvided() error
+	}
+)
+
+var (
+	_ Provider = (*Client)(nil)
+	_ Provider = (*FakeClient)(nil)
+)
+
+// NewProvider creates the backend selected by cfg.AIProvider, the usage of its requests is recorded in store
+// and the prompts edited by the user are loaded from it
+func NewProvider(cfg *config.Config, apiKey string, store Store) Provider {
+	switch cfg.AIProvider {
+	case config.AIProviderFake:
+		return NewFake()
+	default:
+		return New(cfg, apiKey, store)
+	}
+}
+
+// RequiresAPIKey reports whether the selected backend needs a stored API key to work.
+// Keys set in the endpoint configuration, or replaying recorded responses, make the stored key unnecessary.
+func RequiresAPIKey(cfg *config.Config) bool {
+	if cfg.AIProvider == config.AIProviderFake || cfg.Replays() {
+		return false
+	}
+
+	return cfg.TranscribeEndpoint().APIKey == "" ||
+		cfg.ClassifyQuestionsEndpoint().APIKey == "" ||
+		cfg.GenerateQuestionsEndpoint().APIKey == ""
+}
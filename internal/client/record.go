@@ -0,0 +1,275 @@
+package client
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/openai/openai-go/option"
+
+	"github.com/mrbelka12000/interview_parser/internal/config"
+)
+
+// ErrNotRecorded is returned in replay mode for requests without a recorded response
+var ErrNotRecorded = errors.New("no recorded response")
+
+// unsafePathChars matches the characters of model names that cannot be used in a directory name
+var unsafePathChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+type (
+	// recorder saves the responses of the API requests to dir and serves them back in replay mode.
+	// Responses are keyed by the model and the hash of the normalized request, so a request finds its response
+	// whatever endpoint, API key or multipart boundary it is sent with.
+	recorder struct {
+		dir    string
+		replay bool
+	}
+
+	// recording is a saved response, Body holds JSON responses and Text the other ones
+	recording struct {
+		Model       string          `json:"model"`
+		Path        string          `json:"path"`
+		StatusCode  int             `json:"status_code"`
+		ContentType string          `json:"content_type,omitempty"`
+		Body        json.RawMessage `json:"body,omitempty"`
+		Text        string          `json:"text,omitempty"`
+		RecordedAt  time.Time       `json:"recorded_at"`
+	}
+)
+
+// newRecorder returns the recorder of the configured record mode, nil when it is off
+func newRecorder(cfg *config.GPTConfig) *recorder {
+	switch cfg.GPTRecordMode {
+	case config.RecordModeRecord:
+		log.Printf("[I] Recording API responses to %s\n", cfg.GPTRecordDir)
+	case config.RecordModeReplay:
+		log.Printf("[I] Replaying API responses from %s\n", cfg.GPTRecordDir)
+	default:
+		return nil
+	}
+
+	return &recorder{
+		dir:    cfg.GPTRecordDir,
+		replay: cfg.Replays(),
+	}
+}
+
+// middleware saves the successful responses of the requests, or in replay mode answers them with the saved
+// responses without sending them
+func (r *recorder) middleware(req *http.Request, next option.MiddlewareNext) (*http.Response, error) {
+	body, err := readRequestBody(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read request: %w", err)
+	}
+
+	model, hash := requestKey(req, body)
+	path := r.path(model, hash)
+
+	if r.replay {
+		rec, err := loadRecording(path)
+		if err != nil {
+			return nil, err
+		}
+		return rec.response(req), nil
+	}
+
+	res, err := next(req)
+	if err != nil || res.StatusCode != http.StatusOK {
+		return res, err
+	}
+
+	resBody, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	res.Body = io.NopCloser(bytes.NewReader(resBody))
+
+	rec := recording{
+		Model:       model,
+		Path:        endpointPath(req.URL.Path),
+		StatusCode:  res.StatusCode,
+		ContentType: res.Header.Get("Content-Type"),
+		RecordedAt:  time.Now(),
+	}
+	if json.Valid(resBody) {
+		rec.Body = resBody
+	} else {
+		rec.Text = string(resBody)
+	}
+	if err := saveRecording(path, rec); err != nil {
+		// the request itself succeeded
+		log.Printf("[I] Failed to record the response of %s: %v\n", model, err)
+	}
+
+	return res, nil
+}
+
+// path returns the file of the recorded response, grouped by model
+func (r *recorder) path(model, hash string) string {
+	if model == "" {
+		model = "unknown"
+	}
+	return filepath.Join(r.dir, unsafePathChars.ReplaceAllString(model, "_"), hash+".json")
+}
+
+// response rebuilds the HTTP response of the recording
+func (rec *recording) response(req *http.Request) *http.Response {
+	body := []byte(rec.Text)
+	if rec.Body != nil {
+		body = rec.Body
+	}
+
+	header := make(http.Header)
+	if rec.ContentType != "" {
+		header.Set("Content-Type", rec.ContentType)
+	}
+
+	return &http.Response{
+		Status:        http.StatusText(rec.StatusCode),
+		StatusCode:    rec.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
+
+// loadRecording reads the recorded response, failing with ErrNotRecorded when there is none
+func loadRecording(path string) (*recording, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("%w: %s", ErrNotRecorded, path)
+		}
+		return nil, fmt.Errorf("failed to read recorded response: %w", err)
+	}
+
+	var rec recording
+	if err := json.Unmarshal(data, &rec); err != nil {
+		return nil, fmt.Errorf("failed to parse recorded response %s: %w", path, err)
+	}
+
+	return &rec, nil
+}
+
+// saveRecording writes the recording
+func saveRecording(path string, rec recording) error {
+	data, err := json.MarshalIndent(rec, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomic(path, data)
+}
+
+// readRequestBody reads the body of the request and puts it back for sending
+func readRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil {
+		return nil, nil
+	}
+
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+
+	return body, nil
+}
+
+// requestKey returns the model of the request and the hash of its method, path and normalized body:
+// JSON with sorted keys, multipart forms by their fields and file contents without the boundary and file names
+func requestKey(req *http.Request, body []byte) (model, hash string) {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s %s\n", req.Method, endpointPath(req.URL.Path))
+
+	mediaType, params, _ := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	switch {
+	case mediaType == "application/json":
+		var fields map[string]any
+		decoder := json.NewDecoder(bytes.NewReader(body))
+		decoder.UseNumber()
+		if err := decoder.Decode(&fields); err != nil {
+			h.Write(body)
+			break
+		}
+
+		model, _ = fields["model"].(string)
+		// maps are marshaled with sorted keys
+		normalized, _ := json.Marshal(fields)
+		h.Write(normalized)
+	case strings.HasPrefix(mediaType, "multipart/"):
+		var lines []string
+		model, lines = multipartFields(body, params["boundary"])
+		slices.Sort(lines)
+		for _, line := range lines {
+			fmt.Fprintln(h, line)
+		}
+	default:
+		h.Write(body)
+	}
+
+	return model, hex.EncodeToString(h.Sum(nil))
+}
+
+// multipartFields returns the model of the form and its fields as name=value lines, files by their hash
+func multipartFields(body []byte, boundary string) (model string, lines []string) {
+	reader := multipart.NewReader(bytes.NewReader(body), boundary)
+	for {
+		part, err := reader.NextPart()
+		if err != nil {
+			if !errors.Is(err, io.EOF) {
+				// a malformed form is keyed by its whole body
+				lines = append(lines, fmt.Sprintf("malformed=%x", sha256.Sum256(body)))
+			}
+			return model, lines
+		}
+
+		value, err := io.ReadAll(part)
+		if err != nil {
+			lines = append(lines, fmt.Sprintf("malformed=%x", sha256.Sum256(body)))
+			return model, lines
+		}
+
+		if part.FileName() != "" {
+			lines = append(lines, fmt.Sprintf("%s=sha256:%x", part.FormName(), sha256.Sum256(value)))
+			continue
+		}
+		if part.FormName() == "model" {
+			model = string(value)
+		}
+		lines = append(lines, part.FormName()+"="+string(value))
+	}
+}
+
+// endpointPath returns the API path without the base URL, e.g. chat/completions
+func endpointPath(path string) string {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(parts) > 2 {
+		parts = parts[len(parts)-2:]
+	}
+	return strings.Join(parts, "/")
+}
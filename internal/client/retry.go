@@ -0,0 +1,147 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/openai/openai-go"
+)
+
+// maxRetryDelay caps the exponential backoff between retries
+const maxRetryDelay = time.Minute
+
+// rateRequest is what a request counts against the rate limits of its model: the estimated tokens
+// until used reads the tokens from the response
+type rateRequest[T any] struct {
+	model  string
+	tokens int64
+	used   func(res T) int64
+}
+
+// retry runs the request until it succeeds, fails with an error that is not retryable or runs out of attempts.
+// The delay between attempts doubles from GPTRetryBaseDelay, a longer Retry-After asked by the server is respected.
+// Every attempt waits for the rate limits of the model first, the wait does not count against the request timeout.
+func retry[T any](ctx context.Context, c *Client, op string, rate rateRequest[T], request func(ctx context.Context) (T, error)) (T, error) {
+	delay := c.cfg.GPTRetryBaseDelay
+
+	for attempt := 1; ; attempt++ {
+		settle, err := c.throttle(ctx, op, rate.model, rate.tokens)
+		if err != nil {
+			var zero T
+			return zero, err
+		}
+
+		res, err := timed(ctx, c.cfg.GPTRequestTimeout, request)
+		if err == nil && rate.used != nil {
+			settle(rate.used(res))
+		}
+		if err == nil || attempt > c.cfg.GPTRetryAttempts || ctx.Err() != nil || !IsRetryable(err) {
+			return res, err
+		}
+
+		wait := max(delay, retryAfter(err))
+		wait = min(wait+rand.N(wait/4+1), maxRetryDelay)
+		log.Printf("[i] %s failed, retry %d/%d in %v: %v", op, attempt, c.cfg.GPTRetryAttempts, wait.Round(time.Millisecond), err)
+
+		select {
+		case <-ctx.Done():
+			return res, err
+		case <-time.After(wait):
+		}
+		delay *= 2
+	}
+}
+
+// chat sends the chat completion request within the spending and rate limits, retrying it on transient failures,
+// and records its usage
+func (c *Client) chat(ctx context.Context, cl openai.Client, op string, params openai.ChatCompletionNewParams) (*openai.ChatCompletion, error) {
+	promptTokens := estimatePromptTokens(params)
+	release, err := c.reserveBudget(ctx, op, params.Model, 0, promptTokens)
+	if err != nil {
+		return nil, err
+	}
+	// the reservation counts until the usage is recorded
+	defer release()
+
+	rate := rateRequest[*openai.ChatCompletion]{
+		model:  params.Model,
+		tokens: promptTokens,
+		used: func(res *openai.ChatCompletion) int64 {
+			return res.Usage.TotalTokens
+		},
+	}
+	res, err := retry(ctx, c, op, rate, func(ctx context.Context) (*openai.ChatCompletion, error) {
+		return cl.Chat.Completions.New(ctx, params)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	c.recordUsage(ctx, op, params.Model, 0, res.Usage.PromptTokens, res.Usage.CompletionTokens)
+	return res, nil
+}
+
+// timed runs the request with its own timeout, so a hung request fails and can be retried
+func timed[T any](ctx context.Context, timeout time.Duration, request func(ctx context.Context) (T, error)) (T, error) {
+	if timeout <= 0 {
+		return request(ctx)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	return request(ctx)
+}
+
+// IsRetryable reports whether the request failed for a reason that may pass on its own:
+// rate limits, server errors, timeouts and dropped connections
+func IsRetryable(err error) bool {
+	var apiErr *openai.Error
+	if errors.As(err, &apiErr) {
+		switch {
+		case apiErr.Code == "insufficient_quota":
+			// a 429 as well, but it lasts until the billing is fixed
+			return false
+		case apiErr.StatusCode == http.StatusRequestTimeout,
+			apiErr.StatusCode == http.StatusConflict,
+			apiErr.StatusCode == http.StatusTooManyRequests,
+			apiErr.StatusCode >= http.StatusInternalServerError:
+			return true
+		}
+		return false
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
+	return errors.Is(err, context.DeadlineExceeded) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET)
+}
+
+// retryAfter returns the delay asked for by the server with the error, 0 when it did not ask
+func retryAfter(err error) time.Duration {
+	var apiErr *openai.Error
+	if !errors.As(err, &apiErr) || apiErr.Response == nil {
+		return 0
+	}
+
+	if ms, err := strconv.ParseFloat(apiErr.Response.Header.Get("Retry-After-Ms"), 64); err == nil {
+		return time.Duration(ms * float64(time.Millisecond))
+	}
+	if seconds, err := strconv.ParseFloat(apiErr.Response.Header.Get("Retry-After"), 64); err == nil {
+		return time.Duration(seconds * float64(time.Second))
+	}
+
+	return 0
+}
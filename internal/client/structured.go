@@ -0,0 +1,287 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/openai/openai-go"
+	"github.com/openai/openai-go/shared"
+)
+
+const (
+	// maxReasks bounds how many times an unusable response is asked again
+	maxReasks = 2
+
+	reaskPrompt = "Your previous response could not be used: %v. " +
+		"Reply again with only the JSON object in the required structure, without any text or markdown around it."
+)
+
+// trailingCommas matches the commas models sometimes leave before a closing bracket
+var trailingCommas = regexp.MustCompile(`,\s*([}\]])`)
+
+// validator is a response with checks beyond the ranges of its fields
+type validator interface {
+	Validate() error
+}
+
+// chatJSON sends the chat request asking for a JSON response with the schema of out and decodes the response into out.
+// Unless structured outputs are turned off, the schema is enforced by the API; JSON wrapped in prose or markdown
+// is still extracted. A response that cannot be decoded or has fields out of range is asked again, with the error,
+// up to maxReasks times.
+func chatJSON[T any](ctx context.Context, c *Client, cl openai.Client, op string, params openai.ChatCompletionNewParams, out *T) error {
+	if c.cfg.GPTStructuredOutputs {
+		params.ResponseFormat = openai.ChatCompletionNewParamsResponseFormatUnion{
+			OfJSONSchema: &shared.ResponseFormatJSONSchemaParam{
+				JSONSchema: shared.ResponseFormatJSONSchemaJSONSchemaParam{
+					Name:   schemaName(op),
+					Schema: jsonSchema(reflect.TypeOf(out).Elem()),
+					Strict: openai.Bool(true),
+				},
+			},
+		}
+	}
+
+	for attempt := 0; ; attempt++ {
+		res, err := c.chat(ctx, cl, op, params)
+		if err != nil {
+			return err
+		}
+		if len(res.Choices) == 0 {
+			return fmt.Errorf("empty response")
+		}
+
+		message := res.Choices[0].Message
+		if message.Refusal != "" {
+			return fmt.Errorf("model refused to answer: %s", message.Refusal)
+		}
+
+		var result T
+		if err = decodeJSON(message.Content, &result); err == nil {
+			err = validateRanges(reflect.ValueOf(result), "")
+		}
+		if err == nil {
+			if v, ok := any(&result).(validator); ok {
+				err = v.Validate()
+			}
+		}
+		if err == nil {
+			*out = result
+			return nil
+		}
+
+		if attempt == maxReasks {
+			return fmt.Errorf("invalid response after %d attempts: %w", attempt+1, err)
+		}
+		log.Printf("[i] %s returned an invalid response, asking again: %v", op, err)
+
+		params.Messages = append(params.Messages,
+			openai.AssistantMessage(message.Content),
+			openai.UserMessage(fmt.Sprintf(reaskPrompt, err)),
+		)
+	}
+}
+
+// decodeJSON decodes the JSON object of the content. When the content is not JSON as a whole, the object is
+// extracted from the prose or markdown fences around it and trailing commas are removed.
+func decodeJSON(content string, out any) error {
+	err := json.Unmarshal([]byte(content), out)
+	if err == nil {
+		return nil
+	}
+
+	extracted, ok := extractJSON(content)
+	if !ok {
+		return fmt.Errorf("no JSON object in the response: %w", err)
+	}
+	if json.Unmarshal([]byte(extracted), out) == nil {
+		return nil
+	}
+	if repairErr := json.Unmarshal([]byte(trailingCommas.ReplaceAllString(extracted, "$1")), out); repairErr != nil {
+		return fmt.Errorf("failed to unmarshal response: %w", repairErr)
+	}
+
+	return nil
+}
+
+// extractJSON returns the first JSON object of the text, from its opening brace to the matching closing one
+func extractJSON(text string) (string, bool) {
+	start := strings.IndexByte(text, '{')
+	if start < 0 {
+		return "", false
+	}
+
+	var (
+		depth   int
+		inStr   bool
+		escaped bool
+	)
+	for i := start; i < len(text); i++ {
+		ch := text[i]
+		switch {
+		case escaped:
+			escaped = false
+		case inStr && ch == '\\':
+			escaped = true
+		case ch == '"':
+			inStr = !inStr
+		case inStr:
+		case ch == '{':
+			depth++
+		case ch == '}':
+			depth--
+			if depth == 0 {
+				return text[start : i+1], true
+			}
+		}
+	}
+
+	return "", false
+}
+
+// jsonSchema returns the strict JSON schema of the type: every field of its json tag is required,
+// pointers are nullable and `jsonschema:"minimum=0,maximum=1"` tags set the range of numbers
+func jsonSchema(t reflect.Type) map[string]any {
+	switch t.Kind() {
+	case reflect.Pointer:
+		schema := jsonSchema(t.Elem())
+		if typ, ok := schema["type"].(string); ok && typ != "object" {
+			schema["type"] = []string{typ, "null"}
+			return schema
+		}
+		return map[string]any{"anyOf": []any{schema, map[string]any{"type": "null"}}}
+	case reflect.Struct:
+		var (
+			properties = make(map[string]any)
+			required   = make([]string, 0, t.NumField())
+		)
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			name := jsonName(field)
+			if name == "" {
+				continue
+			}
+
+			schema := jsonSchema(field.Type)
+			minimum, maximum, hasMin, hasMax := fieldRange(field)
+			if hasMin {
+				schema["minimum"] = minimum
+			}
+			if hasMax {
+				schema["maximum"] = maximum
+			}
+			properties[name] = schema
+			required = append(required, name)
+		}
+		return map[string]any{
+			"type":                 "object",
+			"properties":           properties,
+			"required":             required,
+			"additionalProperties": false,
+		}
+	case reflect.Slice, reflect.Array:
+		return map[string]any{"type": "array", "items": jsonSchema(t.Elem())}
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	default:
+		return map[string]any{}
+	}
+}
+
+// validateRanges checks the numbers of the value against the ranges of their jsonschema tags, path names the value
+func validateRanges(v reflect.Value, path string) error {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return nil
+		}
+		return validateRanges(v.Elem(), path)
+	case reflect.Slice, reflect.Array:
+		var errs []error
+		for i := 0; i < v.Len(); i++ {
+			if err := validateRanges(v.Index(i), fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		return errors.Join(errs...)
+	case reflect.Struct:
+		var errs []error
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			name := jsonName(field)
+			if name == "" {
+				continue
+			}
+			if path != "" {
+				name = path + "." + name
+			}
+
+			value := v.Field(i)
+			minimum, maximum, hasMin, hasMax := fieldRange(field)
+			if value.CanFloat() && ((hasMin && value.Float() < minimum) || (hasMax && value.Float() > maximum)) {
+				errs = append(errs, fmt.Errorf("%s is %v, it must be between %v and %v", name, value.Float(), minimum, maximum))
+				continue
+			}
+			if err := validateRanges(value, name); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		return errors.Join(errs...)
+	}
+
+	return nil
+}
+
+// fieldRange returns the minimum and maximum of the field set with its jsonschema tag
+func fieldRange(field reflect.StructField) (minimum, maximum float64, hasMin, hasMax bool) {
+	for _, opt := range strings.Split(field.Tag.Get("jsonschema"), ",") {
+		key, value, _ := strings.Cut(opt, "=")
+		n, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			continue
+		}
+		switch key {
+		case "minimum":
+			minimum, hasMin = n, true
+		case "maximum":
+			maximum, hasMax = n, true
+		}
+	}
+
+	return minimum, maximum, hasMin, hasMax
+}
+
+// jsonName returns the name of the field in JSON, empty for fields left out of it
+func jsonName(field reflect.StructField) string {
+	if !field.IsExported() {
+		return ""
+	}
+
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	switch name {
+	case "-":
+		return ""
+	case "":
+		return field.Name
+	}
+
+	return name
+}
+
+// schemaName turns the operation into a schema name of letters, digits and underscores
+func schemaName(op string) string {
+	return strings.ReplaceAll(op, " ", "_")
+}
@@ -0,0 +1,249 @@
+package client
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/openai/openai-go"
+
+	"github.com/mrbelka12000/interview_parser/internal/models"
+)
+
+// Prompt templates the user can edit
+const (
+	PromptAnalyzeInterview      = "analyze_interview"
+	PromptAnalyzeCall           = "analyze_call"
+	PromptGenerateMockInterview = "generate_mock_interview"
+	PromptAnalyzeMockInterview  = "analyze_mock_interview"
+)
+
+const placeholderTranscript = "transcript"
+
+type (
+	// PromptSpec describes a prompt template and the placeholders it is rendered with
+	PromptSpec struct {
+		Name         string   `json:"name"`
+		Description  string   `json:"description"`
+		Placeholders []string `json:"placeholders"`
+	}
+
+	// PromptPreview is a template rendered into the messages sent to the model.
+	// User is empty when the template places the transcript itself.
+	PromptPreview struct {
+		System string `json:"system"`
+		User   string `json:"user,omitempty"`
+	}
+
+	// PromptStore keeps the prompt templates edited by the user
+	PromptStore interface {
+		// GetPromptTemplate returns the latest version of the template, nil when the built-in one was not edited
+		GetPromptTemplate(name string, lang models.Language) (*models.PromptTemplate, error)
+	}
+
+	// PromptVersionStore lists the saved versions of the templates
+	PromptVersionStore interface {
+		GetPromptVersions(name string, lang models.Language) ([]models.PromptTemplate, error)
+	}
+
+	// Store records the usage of the requests and keeps the edited prompts
+	Store interface {
+		UsageRecorder
+		PromptStore
+	}
+)
+
+var (
+	// PromptSpecs are the editable templates. A template of a transcript analysis without {{transcript}}
+	// is sent as the system message followed by the transcript.
+	PromptSpecs = []PromptSpec{
+		{
+			Name:         PromptAnalyzeInterview,
+			Description:  "Extracts the interviewer questions from an interview transcript and rates the answers",
+			Placeholders: []string{placeholderTranscript},
+		},
+		{
+			Name:         PromptAnalyzeCall,
+			Description:  "Extracts topics, tasks, blockers and next steps from a meeting transcript",
+			Placeholders: []string{placeholderTranscript},
+		},
+		{
+			Name:         PromptGenerateMockInterview,
+			Description:  "Generates the questions of a mock interview",
+			Placeholders: []string{"cv", "vacancy", "specialization", "level", "meta", "questions_count"},
+		},
+		{
+			Name:         PromptAnalyzeMockInterview,
+			Description:  "Evaluates the answers given in a mock interview",
+			Placeholders: []string{"cv", "vacancy", "specialization", "level", "meta", "questions", "answers"},
+		},
+	}
+
+	// promptSamples fill the placeholders of previews
+	promptSamples = map[string]string{
+		placeholderTranscript: "Interviewer: How do goroutines differ from threads?\nCandidate: They are scheduled by the Go runtime and start with a small stack.",
+		"cv":                  "Go developer, 5 years of experience with PostgreSQL, Kafka and Kubernetes.",
+		"vacancy":             "Senior Go developer for a payments platform.",
+		"specialization":      "Go Developer",
+		"level":               "Senior",
+		"meta":                "Remote team, on-call rotation.",
+		"questions_count":     "5",
+		"questions":           "How do goroutines differ from threads?",
+		"answers":             "They are scheduled by the Go runtime and start with a small stack.",
+	}
+
+	placeholderPattern = regexp.MustCompile(`\{\{\s*(\w+)\s*\}\}`)
+)
+
+// DefaultPrompt returns the built-in template of the name in the language as version 0
+func DefaultPrompt(name string, lang models.Language) (*models.PromptTemplate, error) {
+	var body string
+	switch name {
+	case PromptAnalyzeInterview:
+		body = getAnalyzePrompt(lang)
+	case PromptAnalyzeCall:
+		body = getCallAnalyzePrompt(lang)
+	case PromptGenerateMockInterview:
+		body = getGenerateMockInterviewPrompt(lang)
+	case PromptAnalyzeMockInterview:
+		body = getAnalyzeMockInterviewPrompt(lang)
+	default:
+		return nil, fmt.Errorf("unknown prompt: %q", name)
+	}
+
+	return &models.PromptTemplate{Name: name, Language: lang, Body: body}, nil
+}
+
+// PromptLanguage parses the language of a prompt template, templates are kept per language so auto is rejected
+func PromptLanguage(code string) (models.Language, error) {
+	lang, err := models.ParseLanguage(code)
+	if err != nil {
+		return "", err
+	}
+	if lang.IsAuto() {
+		return "", fmt.Errorf("prompt templates are kept per language, use ru or en")
+	}
+
+	return lang, nil
+}
+
+// PromptVersions returns the built-in template of the name in the language as version 0 followed by the saved versions
+func PromptVersions(store PromptVersionStore, name string, lang models.Language) ([]models.PromptTemplate, error) {
+	def, err := DefaultPrompt(name, lang)
+	if err != nil {
+		return nil, err
+	}
+
+	saved, err := store.GetPromptVersions(name, lang)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get prompt versions: %w", err)
+	}
+
+	return append([]models.PromptTemplate{*def}, saved...), nil
+}
+
+// ValidatePrompt checks that the template body is not empty and uses only the placeholders of the name
+func ValidatePrompt(name, body string) error {
+	spec, ok := promptSpec(name)
+	if !ok {
+		return fmt.Errorf("unknown prompt: %q", name)
+	}
+	if strings.TrimSpace(body) == "" {
+		return fmt.Errorf("prompt cannot be empty")
+	}
+
+	for _, match := range placeholderPattern.FindAllStringSubmatch(body, -1) {
+		if !slices.Contains(spec.Placeholders, match[1]) {
+			return fmt.Errorf("unknown placeholder %s, %s supports: {{%s}}", match[0], name, strings.Join(spec.Placeholders, "}}, {{"))
+		}
+	}
+
+	return nil
+}
+
+// PreviewPrompt renders the template body of the name with sample values into the messages sent to the model
+func PreviewPrompt(name string, lang models.Language, body string) (PromptPreview, error) {
+	if err := ValidatePrompt(name, body); err != nil {
+		return PromptPreview{}, err
+	}
+
+	tpl := &models.PromptTemplate{Name: name, Language: lang, Body: body}
+	switch name {
+	case PromptAnalyzeInterview, PromptAnalyzeCall:
+		system, user := renderTranscriptPrompt(tpl, promptSamples[placeholderTranscript])
+		return PromptPreview{System: system, User: user}, nil
+	default:
+		return PromptPreview{System: renderPrompt(body, promptSamples)}, nil
+	}
+}
+
+// prompt returns the template of the name in the language: the latest version edited by the user, or the built-in one
+func (c *Client) prompt(name string, lang models.Language) *models.PromptTemplate {
+	if c.prompts != nil {
+		tpl, err := c.prompts.GetPromptTemplate(name, lang)
+		if err != nil {
+			log.Printf("[I] Failed to load prompt %s/%s, using the built-in one: %v\n", name, lang, err)
+		} else if tpl != nil {
+			return tpl
+		}
+	}
+
+	tpl, _ := DefaultPrompt(name, lang)
+	return tpl
+}
+
+// transcriptMessages renders the transcript analysis template into the messages sent to the model
+func transcriptMessages(tpl *models.PromptTemplate, transcript string) []openai.ChatCompletionMessageParamUnion {
+	system, user := renderTranscriptPrompt(tpl, transcript)
+	if user == "" {
+		return []openai.ChatCompletionMessageParamUnion{openai.SystemMessage(system)}
+	}
+
+	return []openai.ChatCompletionMessageParamUnion{openai.SystemMessage(system), openai.UserMessage(user)}
+}
+
+// renderTranscriptPrompt renders the transcript analysis template. Unless the template places the transcript,
+// it follows in the user message: under the transcript header for interviews, as is for calls.
+func renderTranscriptPrompt(tpl *models.PromptTemplate, transcript string) (system, user string) {
+	values := map[string]string{placeholderTranscript: transcript}
+	system = renderPrompt(tpl.Body, values)
+	if hasPlaceholder(tpl.Body, placeholderTranscript) {
+		return system, ""
+	}
+
+	if tpl.Name == PromptAnalyzeInterview {
+		return system, renderPrompt(getTranscriptPrompt(tpl.Language), values)
+	}
+	return system, transcript
+}
+
+// renderPrompt replaces the placeholders of the body with the values, unknown ones are kept as is
+func renderPrompt(body string, values map[string]string) string {
+	return placeholderPattern.ReplaceAllStringFunc(body, func(match string) string {
+		if value, ok := values[placeholderPattern.FindStringSubmatch(match)[1]]; ok {
+			return value
+		}
+		return match
+	})
+}
+
+// hasPlaceholder reports whether the body uses the placeholder
+func hasPlaceholder(body, name string) bool {
+	for _, match := range placeholderPattern.FindAllStringSubmatch(body, -1) {
+		if match[1] == name {
+			return true
+		}
+	}
+	return false
+}
+
+func promptSpec(name string) (PromptSpec, bool) {
+	for _, spec := range PromptSpecs {
+		if spec.Name == name {
+			return spec, true
+		}
+	}
+	return PromptSpec{}, false
+}
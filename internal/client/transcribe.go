@@ -2,30 +2,105 @@ package client
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"log"
 	"os"
 
 	"github.com/openai/openai-go"
+
+	"github.com/mrbelka12000/interview_parser/internal/config"
+	"github.com/mrbelka12000/interview_parser/internal/models"
 )
 
-func (c *Client) Transcribe(ctx context.Context, chunkPath string) (string, error) {
+// verboseTranscription is the part of the verbose_json response with segment timings
+type verboseTranscription struct {
+	Duration float64                    `json:"duration"`
+	Segments []models.TranscriptSegment `json:"segments"`
+}
+
+func (c *Client) Transcribe(ctx context.Context, chunkPath string, duration float64, lang models.Language) (out models.Transcript, err error) {
 	log.Printf("[i] Transcribing media from %s", chunkPath)
 
 	f, err := os.Open(chunkPath)
 	if err != nil {
-		return "", fmt.Errorf("%v file open error: %w", chunkPath, err)
+		return out, fmt.Errorf("%v file open error: %w", chunkPath, err)
 	}
 
 	defer f.Close()
 
-	res, err := c.cl.Audio.Transcriptions.New(ctx, openai.AudioTranscriptionNewParams{
-		Model: c.cfg.GPTTranscribeModel,
+	params := openai.AudioTranscriptionNewParams{
+		Model: c.cfg.ChunkTranscribeModel(),
 		File:  f,
+	}
+	if config.SupportsSegments(params.Model) {
+		params.ResponseFormat = openai.AudioResponseFormatVerboseJSON
+		params.TimestampGranularities = []string{"segment"}
+	}
+	if lang.IsAuto() {
+		lang = c.cfg.AnalysisLanguage
+	}
+	if !lang.IsAuto() {
+		params.Language = openai.String(string(lang))
+	}
+
+	// the same audio transcribed by the same model with the same hint is paid for once
+	hash, err := fileHash(f)
+	if err != nil {
+		return out, fmt.Errorf("%v file read error: %w", chunkPath, err)
+	}
+	key := cacheKey(OpTranscribe, params.Model, string(params.ResponseFormat), string(lang), hash)
+	if c.cache.get(key, &out) {
+		log.Printf("[i] Using the cached transcript of %s", chunkPath)
+		return out, nil
+	}
+
+	op := OpTranscribe + " " + chunkPath
+	release, err := c.reserveBudget(ctx, op, params.Model, duration, 0)
+	if err != nil {
+		return out, err
+	}
+	// the reservation counts until the usage is recorded
+	defer release()
+
+	// the audio tokens are known only from the response
+	rate := rateRequest[*openai.Transcription]{
+		model: params.Model,
+		used: func(res *openai.Transcription) int64 {
+			return res.Usage.InputTokens + res.Usage.OutputTokens
+		},
+	}
+	res, err := retry(ctx, c, op, rate, func(ctx context.Context) (*openai.Transcription, error) {
+		// every attempt uploads the chunk from the beginning
+		if _, err := f.Seek(0, io.SeekStart); err != nil {
+			return nil, err
+		}
+		return c.transcribeCl.Audio.Transcriptions.New(ctx, params)
 	})
 	if err != nil {
-		return "", fmt.Errorf("%v transcribe error: %w", chunkPath, err)
+		return out, fmt.Errorf("%v transcribe error: %w", chunkPath, err)
+	}
+
+	out.Text = res.Text
+	var verbose verboseTranscription
+	if params.ResponseFormat == openai.AudioResponseFormatVerboseJSON {
+		if err := json.Unmarshal([]byte(res.RawJSON()), &verbose); err != nil {
+			return out, fmt.Errorf("%v failed to parse segments: %w", chunkPath, err)
+		}
+		out.Segments = verbose.Segments
+	}
+
+	// the audio duration is reported with the usage by gpt-4o models and with the segments by whisper
+	seconds := res.Usage.Seconds
+	if seconds == 0 {
+		seconds = verbose.Duration
+	}
+	if seconds == 0 {
+		seconds = duration
 	}
+	c.recordUsage(ctx, OpTranscribe, params.Model, seconds, res.Usage.InputTokens, res.Usage.OutputTokens)
+	c.cache.put(key, out)
 
-	return res.Text, nil
+	return out, nil
 }
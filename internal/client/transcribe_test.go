@@ -0,0 +1,88 @@
+package client
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/mrbelka12000/interview_parser/internal/config"
+	"github.com/mrbelka12000/interview_parser/internal/models"
+)
+
+// usageStub keeps the recorded usage, nothing was spent before
+type usageStub struct {
+	mx     sync.Mutex
+	usages []models.Usage
+}
+
+func (u *usageStub) RecordUsage(usage models.Usage) {
+	u.mx.Lock()
+	defer u.mx.Unlock()
+	u.usages = append(u.usages, usage)
+}
+
+func (u *usageStub) GetSpending(uint64) (float64, float64, error) {
+	return 0, 0, nil
+}
+
+func TestTranscribeReservesChunkDuration(t *testing.T) {
+	var (
+		c        *Client
+		reserved float64
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.budget.mx.Lock()
+		reserved = c.budget.month
+		c.budget.mx.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"text": "hello there", "usage": {"type": "tokens", "input_tokens": 10, "output_tokens": 2}}`))
+	}))
+	defer srv.Close()
+
+	cfg := &config.Config{
+		GPTConfig: config.GPTConfig{
+			GPTTranscribeModel: "gpt-4o-transcribe",
+			GPTPrices:          map[string]config.ModelPrice{"gpt-4o-transcribe": {AudioMinute: 0.006}},
+			GPTEndpoints: config.GPTEndpoints{
+				GPTTranscribeEndpoint: config.GPTEndpoint{BaseURL: srv.URL},
+			},
+		},
+		LimitsConfig: config.LimitsConfig{SpendingMonthlyLimit: 10},
+	}
+	usage := &usageStub{}
+	c = &Client{
+		transcribeCl: newOpenAIClient("test", cfg.TranscribeEndpoint(), nil),
+		cfg:          *cfg,
+		usage:        usage,
+	}
+
+	// the ffmpeg splitter makes m4a chunks, their duration is known only from the split
+	chunk := filepath.Join(t.TempDir(), "upload_chunk_0.m4a")
+	if err := os.WriteFile(chunk, []byte("not a wav file"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.Transcribe(context.Background(), chunk, 60, models.LanguageEN); err != nil {
+		t.Fatal(err)
+	}
+
+	if want := 0.006; !closeTo(reserved, want) {
+		t.Errorf("reserved $%v while the request was in flight, want $%v", reserved, want)
+	}
+	if c.budget.month != 0 {
+		t.Errorf("$%v is still reserved after the usage was recorded", c.budget.month)
+	}
+	if len(usage.usages) != 1 || usage.usages[0].AudioSeconds != 60 {
+		t.Errorf("recorded usage = %+v, want one request of 60 audio seconds", usage.usages)
+	}
+}
+
+func closeTo(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
@@ -0,0 +1,73 @@
+package client
+
+import (
+	"context"
+
+	"github.com/mrbelka12000/interview_parser/internal/models"
+)
+
+// Operations recorded with the usage of their requests
+const (
+	OpTranscribe            = "transcribe"
+	OpDiarize               = "diarize"
+	OpAnalyzeTranscript     = "analyze transcript"
+	OpAnalyzeCall           = "analyze call"
+	OpGenerateMockInterview = "generate mock interview"
+	OpAnalyzeMockInterview  = "analyze mock interview"
+)
+
+type (
+	// UsageRecorder saves the usage of the API requests and reports what they cost so far
+	UsageRecorder interface {
+		// RecordUsage receives the usage of every successful request
+		RecordUsage(usage models.Usage)
+		// GetSpending returns the cost of the requests made this month and of the requests made by the job
+		GetSpending(jobID uint64) (month, job float64, err error)
+	}
+
+	// jobKey is the context key of the id of the job the requests are made for
+	jobKey struct{}
+	// interviewKey is the context key of the id of the saved interview the requests are made for
+	interviewKey struct{}
+)
+
+// WithJob returns a context whose requests are recorded as made for the job
+func WithJob(ctx context.Context, jobID uint64) context.Context {
+	return context.WithValue(ctx, jobKey{}, jobID)
+}
+
+// JobFromContext returns the id of the job the requests of ctx are made for, 0 outside of jobs
+func JobFromContext(ctx context.Context) uint64 {
+	id, _ := ctx.Value(jobKey{}).(uint64)
+	return id
+}
+
+// WithInterview returns a context whose requests are recorded as made for the saved interview,
+// e.g. when it is analyzed again outside of a job
+func WithInterview(ctx context.Context, interviewID uint64) context.Context {
+	return context.WithValue(ctx, interviewKey{}, interviewID)
+}
+
+// InterviewFromContext returns the id of the interview the requests of ctx are made for, 0 when it is not set
+func InterviewFromContext(ctx context.Context) uint64 {
+	id, _ := ctx.Value(interviewKey{}).(uint64)
+	return id
+}
+
+// recordUsage reports the usage of a request with its estimated cost
+func (c *Client) recordUsage(ctx context.Context, op, model string, audioSeconds float64, promptTokens, completionTokens int64) {
+	if c.usage == nil {
+		return
+	}
+
+	c.usage.RecordUsage(models.Usage{
+		Operation:        op,
+		Model:            model,
+		AudioSeconds:     audioSeconds,
+		PromptTokens:     promptTokens,
+		CompletionTokens: completionTokens,
+		Cost:             c.cfg.Cost(model, audioSeconds, promptTokens, completionTokens),
+		JobID:            JobFromContext(ctx),
+		InterviewID:      InterviewFromContext(ctx),
+	})
+}
@@ -4,37 +4,85 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 	"strings"
 
 	"github.com/openai/openai-go"
+
+	"github.com/mrbelka12000/interview_parser/internal/config"
 )
 
+const openAIBaseURL = "https://api.openai.com/v1"
+
 func (c *Client) IsValidAPIKeysProvided() error {
+	if c.cfg.Replays() {
+		// no request reaches the API
+		return nil
+	}
 
-	_, err := c.cl.Audio.Transcriptions.New(context.Background(), openai.AudioTranscriptionNewParams{
-		Model: c.cfg.GPTTranscribeModel,
+	// The transcription request has no file on purpose: a validation error means
+	// the endpoint accepted the key and the model, without uploading any audio.
+	_, err := c.transcribeCl.Audio.Transcriptions.New(context.Background(), openai.AudioTranscriptionNewParams{
+		Model: c.cfg.ChunkTranscribeModel(),
 	})
-	if err != nil && !strings.Contains(err.Error(), "Field required") {
-		if strings.Contains(err.Error(), "does not have access to model") {
-			return errors.New(fmt.Sprintf(`Your project does not have access to model: %s
-You can add it here: https://platform.openai.com/settings/proj_qJmmMi9x0FgRhq9KgNLsq2xw/limits`, c.cfg.GPTTranscribeModel))
-		}
-		return errors.New("Invalid API Key")
+	if err = endpointError(err, c.cfg.TranscribeEndpoint(), c.cfg.ChunkTranscribeModel(), true); err != nil {
+		return err
 	}
 
-	_, err = c.cl.Chat.Completions.New(context.Background(), openai.ChatCompletionNewParams{
+	_, err = c.classifyCl.Chat.Completions.New(context.Background(), openai.ChatCompletionNewParams{
 		Messages: []openai.ChatCompletionMessageParamUnion{
 			openai.UserMessage("Hello, it is test request!"),
 		},
 		Model: c.cfg.GPTClassifyQuestionsModel,
 	})
-	if err != nil {
-		if strings.Contains(err.Error(), "does not have access to model") {
-			return errors.New(fmt.Sprintf(`Your project does not have access to model: %s
-You can add it here: https://platform.openai.com/settings/proj_qJmmMi9x0FgRhq9KgNLsq2xw/limits`, c.cfg.GPTClassifyQuestionsModel))
-		}
-		return errors.New("Invalid API Key")
+	if err = endpointError(err, c.cfg.ClassifyQuestionsEndpoint(), c.cfg.GPTClassifyQuestionsModel, false); err != nil {
+		return err
+	}
+
+	_, err = c.generateCl.Chat.Completions.New(context.Background(), openai.ChatCompletionNewParams{
+		Messages: []openai.ChatCompletionMessageParamUnion{
+			openai.UserMessage("Hello, it is test request!"),
+		},
+		Model: c.cfg.GPTGenerateQuestionsModel,
+	})
+	if err = endpointError(err, c.cfg.GenerateQuestionsEndpoint(), c.cfg.GPTGenerateQuestionsModel, false); err != nil {
+		return err
 	}
 
 	return nil
 }
+
+// endpointError converts a validation request error into a user facing message based on the HTTP status.
+// incompleteRequest marks requests sent without required fields, where a validation error is expected.
+func endpointError(err error, endpoint config.GPTEndpoint, model string, incompleteRequest bool) error {
+	if err == nil {
+		return nil
+	}
+
+	baseURL := strings.TrimSuffix(endpoint.BaseURL, "/")
+	if baseURL == "" {
+		baseURL = openAIBaseURL
+	}
+
+	var apiErr *openai.Error
+	if !errors.As(err, &apiErr) {
+		return fmt.Errorf("Request to %s failed: %v", baseURL, err)
+	}
+
+	switch apiErr.StatusCode {
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		if incompleteRequest {
+			return nil
+		}
+	case http.StatusUnauthorized:
+		return fmt.Errorf("Invalid API Key for %s", baseURL)
+	case http.StatusForbidden, http.StatusNotFound:
+		msg := fmt.Sprintf("%s does not provide access to model: %s", baseURL, model)
+		if baseURL == openAIBaseURL {
+			msg += "\nCheck the model access in your OpenAI project settings: https://platform.openai.com/settings"
+		}
+		return errors.New(msg)
+	}
+
+	return fmt.Errorf("%s rejected the request for model %s (status %d): %s", baseURL, model, apiErr.StatusCode, apiErr.Message)
+}
@@ -7,16 +7,23 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/sethvargo/go-envconfig"
+
+	"github.com/mrbelka12000/interview_parser/internal/models"
 )
 
 type (
 	Config struct {
 		ServiceConfig
 		WSConfig
+		HTTPConfig
 		GPTConfig
+		LimitsConfig
 		TranscribeConfig
 		LocalConfig
 		DBConfig
@@ -33,22 +40,89 @@ type (
 		WSServerPort int `env:"WS_SERVER_PORT, default=35044"`
 	}
 
+	HTTPConfig struct {
+		HTTPServerPort    int    `env:"HTTP_SERVER_PORT, default=8080"`
+		HTTPAuthToken     string `env:"HTTP_AUTH_TOKEN"`
+		HTTPMaxUploadSize int64  `env:"HTTP_MAX_UPLOAD_SIZE_MB, default=1024"`
+	}
+
 	GPTConfig struct {
+		AIProvider                string `env:"AI_PROVIDER, default=openai"`
 		GPTTranscribeModel        string `env:"GPT_TRANSCRIBE_MODEL, default=gpt-4o-transcribe"`
 		GPTClassifyQuestionsModel string `env:"GPT_CLASSIFY_QUESTIONS_MODEL, default=o3"`
 		GPTGenerateQuestionsModel string `env:"GPT_GENERATE_QUESTIONS_MODEL, default=gpt-4.1"`
+		GPTDiarizeModel           string `env:"GPT_DIARIZE_MODEL, default=gpt-4.1-mini"`
+		// GPTSegmentsModel transcribes the chunks when GPTTranscribeModel does not return segment timestamps,
+		// "off" keeps GPTTranscribeModel and the transcripts are timed by whole chunks
+		GPTSegmentsModel string `env:"GPT_SEGMENTS_MODEL, default=whisper-1"`
+		// GPTRetryAttempts is how many times a request failed with a rate limit, server error or timeout is retried
+		GPTRetryAttempts int `env:"GPT_RETRY_ATTEMPTS, default=4"`
+		// GPTRetryBaseDelay is the delay before the first retry, doubled for every next one
+		GPTRetryBaseDelay time.Duration `env:"GPT_RETRY_BASE_DELAY, default=2s"`
+		// GPTRequestTimeout limits a single request, 0 waits as long as it takes
+		GPTRequestTimeout time.Duration `env:"GPT_REQUEST_TIMEOUT, default=10m"`
+		// GPTStructuredOutputs asks for responses constrained to the JSON schema of the expected result,
+		// turned off for OpenAI-compatible servers that do not support it
+		GPTStructuredOutputs bool `env:"GPT_STRUCTURED_OUTPUTS, default=true"`
+		// AnalysisLanguage is the language of the prompts and the transcription hint when a job does not set one:
+		// ru, en or auto to detect it from the transcript
+		AnalysisLanguage models.Language `env:"ANALYSIS_LANGUAGE, default=auto"`
+		// GPTRecordMode is "record" to save the responses of the API requests to GPTRecordDir, "replay" to serve
+		// the requests from the saved responses without calling the API, or "off"
+		GPTRecordMode string `env:"GPT_RECORD_MODE, default=off"`
+		GPTRecordDir  string `env:"GPT_RECORD_DIR"`
+		// GPTCacheDir keeps the responses of transcription and analysis requests, so an input is paid for once
+		GPTCacheDir string `env:"GPT_CACHE_DIR"`
+		// GPTCacheMaxSizeMB limits the size of GPTCacheDir, the least recently used responses are removed over it.
+		// 0 turns the cache off.
+		GPTCacheMaxSizeMB int64 `env:"GPT_CACHE_MAX_SIZE_MB, default=512"`
+		// GPTPricesFile is a JSON file with model prices replacing or adding to the default ones
+		GPTPricesFile string `env:"GPT_PRICES_FILE"`
+		// GPTPrices is the price table usage costs are estimated with, by model
+		GPTPrices map[string]ModelPrice
+		GPTEndpoints
+	}
+
+	// GPTEndpoints configures OpenAI-compatible servers. Per-task settings
+	// override the shared OPENAI_* ones field by field.
+	GPTEndpoints struct {
+		OpenAIEndpoint               GPTEndpoint `env:", prefix=OPENAI_"`
+		GPTTranscribeEndpoint        GPTEndpoint `env:", prefix=GPT_TRANSCRIBE_"`
+		GPTClassifyQuestionsEndpoint GPTEndpoint `env:", prefix=GPT_CLASSIFY_QUESTIONS_"`
+		GPTGenerateQuestionsEndpoint GPTEndpoint `env:", prefix=GPT_GENERATE_QUESTIONS_"`
+	}
+
+	GPTEndpoint struct {
+		BaseURL      string            `env:"BASE_URL"`
+		APIKey       string            `env:"API_KEY"`
+		Organization string            `env:"ORGANIZATION"`
+		Project      string            `env:"PROJECT"`
+		Headers      map[string]string `env:"HEADERS"`
 	}
 
 	TranscribeConfig struct {
 		ChunkSeconds int `env:"CHUNK_SECONDS, default=100"`
+		// ChunkSilenceWindow is how many seconds around ChunkSeconds are searched for a pause to cut at, 0 cuts at fixed length
+		ChunkSilenceWindow int `env:"CHUNK_SILENCE_WINDOW, default=20"`
+		// SilenceThresholdDB is the loudness below which audio is silence
+		SilenceThresholdDB int `env:"SILENCE_THRESHOLD_DB, default=-35"`
+		// SilenceMinDuration is the shortest pause in seconds
+		SilenceMinDuration float64 `env:"SILENCE_MIN_DURATION, default=0.5"`
+		// DropSilenceSeconds leaves silences longer than this out of the transcription, 0 keeps them
+		DropSilenceSeconds float64 `env:"DROP_SILENCE_SECONDS, default=0"`
+		// ChunkOverlapSeconds is how much of the previous chunk is repeated at the start of the next one
+		ChunkOverlapSeconds float64 `env:"CHUNK_OVERLAP_SECONDS, default=2"`
+		Diarize             bool    `env:"DIARIZE, default=true"`
 	}
 
 	LocalConfig struct {
-		DefaultDir            string
+		DefaultDir            string `env:"DATA_DIR"`
 		DefaultTranscriptDir  string
 		DefaultAnalyzeDir     string
 		DefaultAnalyzeCallDir string
-		ChunksDir             string
+		// RecordingDir keeps the recordings in progress, next to the calls so they are saved with a rename
+		RecordingDir string
+		ChunksDir    string
 	}
 
 	DBConfig struct {
@@ -60,6 +134,11 @@ type (
 		AudioSampleRate uint32 `env:"AUDIO_SAMPLE_RATE, default=48000"`
 		AudioChannels   uint32 `env:"AUDIO_CHANNELS, default=2"`
 		AudioBitrate    uint16 `env:"AUDIO_BITRATE, default=16"`
+		// AudioSeparateTracks saves the mic and the system audio next to the mixed recording
+		AudioSeparateTracks bool `env:"AUDIO_SEPARATE_TRACKS, default=true"`
+		// AudioLoopbackDevicePattern is a case-insensitive regular expression matching the names of
+		// the capture devices carrying the system audio
+		AudioLoopbackDevicePattern string `env:"AUDIO_LOOPBACK_DEVICE_PATTERN"`
 	}
 )
 
@@ -69,18 +148,45 @@ const (
 	defaultAnalyzeDir                = "analyzes"
 	defaultAnalyzeCallDir            = "calls"
 	defaultChunksDir                 = "output/chunks"
+	defaultRecordingDir              = ".recording"
 	defaultChunksSeconds             = 200
+	defaultChunkSilenceWindow        = 20
+	defaultSilenceThresholdDB        = -35
+	defaultSilenceMinDuration        = 0.5
+	defaultChunkOverlapSeconds       = 2
 	defaultGPTClassifyQuestionsModel = "o3"
 	defaultGPTGenerateQuestionsModel = "gpt-4.1"
+	defaultGPTDiarizeModel           = "gpt-4.1-mini"
 	defaultGPTTranscribeModels       = "gpt-4o-transcribe"
+	defaultGPTSegmentsModel          = "whisper-1"
+	defaultGPTRetryAttempts          = 4
+	defaultGPTRetryBaseDelay         = 2 * time.Second
+	defaultGPTRequestTimeout         = 10 * time.Minute
+	defaultPricesFile                = "prices.json"
+	defaultRecordDir                 = "gpt_records"
+	defaultCacheDir                  = "cache"
+	defaultGPTCacheMaxSizeMB         = 512
 	defaultAudioSampleRate           = 48000
 	defaultAudioChannels             = 2
 	defaultAudioBitrate              = 16
 	defaultWSServerPort              = 35044
+	defaultHTTPServerPort            = 8080
+	defaultHTTPMaxUploadSize         = 1024
 	defaultServiceName               = "interview_parser"
 
+	// loopback devices: BlackHole on macOS, PulseAudio/PipeWire monitor sources on Linux
+	loopbackDevicePatternDarwin = "blackhole"
+	loopbackDevicePatternLinux  = `^monitor of |\.monitor$`
+
 	ENVProduction = "PRODUCTION"
 	ENVLocal      = "LOCAL"
+
+	AIProviderOpenAI = "openai"
+	AIProviderFake   = "fake"
+
+	RecordModeOff    = "off"
+	RecordModeRecord = "record"
+	RecordModeReplay = "replay"
 )
 
 func ParseConfig() *Config {
@@ -97,6 +203,48 @@ func ParseConfig() *Config {
 			return nil
 		}
 
+		if cfg.DefaultDir == "" {
+			cfg.DefaultDir = defaultDirName
+		}
+		cfg.LocalConfig = newLocalConfig(cfg.DefaultDir)
+		if cfg.DBConfig.Path == "" {
+			cfg.DBConfig.Path = filepath.Join(cfg.DefaultDir, "local.db")
+		}
+		if cfg.AudioLoopbackDevicePattern == "" {
+			cfg.AudioLoopbackDevicePattern = defaultLoopbackDevicePattern()
+		}
+		if cfg.GPTPricesFile == "" {
+			cfg.GPTPricesFile = filepath.Join(cfg.DefaultDir, defaultPricesFile)
+		}
+		if cfg.GPTPrices, err = loadPrices(cfg.GPTPricesFile); err != nil {
+			log.Printf("[I] Error loading model prices: %v\n", err)
+			return nil
+		}
+		if cfg.GPTRecordDir == "" {
+			cfg.GPTRecordDir = filepath.Join(cfg.DefaultDir, defaultRecordDir)
+		}
+		if cfg.GPTCacheDir == "" {
+			cfg.GPTCacheDir = filepath.Join(cfg.DefaultDir, defaultCacheDir)
+		}
+		if err = validateRecordMode(cfg.GPTRecordMode); err != nil {
+			log.Printf("[I] Error parsing record mode: %v\n", err)
+			return nil
+		}
+		if cfg.GPTRateLimits, err = parseRateLimits(cfg.GPTModelRateLimits); err != nil {
+			log.Printf("[I] Error parsing rate limits: %v\n", err)
+			return nil
+		}
+		if cfg.AnalysisLanguage, err = models.ParseLanguage(string(cfg.AnalysisLanguage)); err != nil {
+			log.Printf("[I] Error parsing analysis language: %v\n", err)
+			return nil
+		}
+
+		if err := os.MkdirAll(cfg.DefaultDir, os.ModePerm); err != nil {
+			log.Printf("[I] Failed to create data directory: %v\n", err)
+			return nil
+		}
+		createLocalDirs(&cfg)
+
 		return &cfg
 	}
 
@@ -125,31 +273,99 @@ func ParseConfig() *Config {
 		WSConfig: WSConfig{
 			WSServerPort: defaultWSServerPort,
 		},
+		HTTPConfig: HTTPConfig{
+			HTTPServerPort:    defaultHTTPServerPort,
+			HTTPMaxUploadSize: defaultHTTPMaxUploadSize,
+		},
 		GPTConfig: GPTConfig{
+			AIProvider:                getEnv("AI_PROVIDER", AIProviderOpenAI),
 			GPTTranscribeModel:        defaultGPTTranscribeModels,
 			GPTClassifyQuestionsModel: defaultGPTClassifyQuestionsModel,
 			GPTGenerateQuestionsModel: defaultGPTGenerateQuestionsModel,
+			GPTDiarizeModel:           getEnv("GPT_DIARIZE_MODEL", defaultGPTDiarizeModel),
+			GPTSegmentsModel:          getEnv("GPT_SEGMENTS_MODEL", defaultGPTSegmentsModel),
+			GPTRetryAttempts:          getEnvInt("GPT_RETRY_ATTEMPTS", defaultGPTRetryAttempts),
+			GPTRetryBaseDelay:         getEnvDuration("GPT_RETRY_BASE_DELAY", defaultGPTRetryBaseDelay),
+			GPTRequestTimeout:         getEnvDuration("GPT_REQUEST_TIMEOUT", defaultGPTRequestTimeout),
+			GPTStructuredOutputs:      getEnv("GPT_STRUCTURED_OUTPUTS", "true") != "false",
+			AnalysisLanguage:          models.Language(getEnv("ANALYSIS_LANGUAGE", string(models.LanguageAuto))),
+			GPTRecordMode:             getEnv("GPT_RECORD_MODE", RecordModeOff),
+			GPTRecordDir:              getEnv("GPT_RECORD_DIR", filepath.Join(defaultDir, defaultRecordDir)),
+			GPTCacheDir:               getEnv("GPT_CACHE_DIR", filepath.Join(defaultDir, defaultCacheDir)),
+			GPTCacheMaxSizeMB:         int64(getEnvInt("GPT_CACHE_MAX_SIZE_MB", defaultGPTCacheMaxSizeMB)),
+			GPTPricesFile:             getEnv("GPT_PRICES_FILE", filepath.Join(defaultDir, defaultPricesFile)),
 		},
 		TranscribeConfig: TranscribeConfig{
-			ChunkSeconds: defaultChunksSeconds,
-		},
-		LocalConfig: LocalConfig{
-			DefaultDir:            defaultDir,
-			DefaultTranscriptDir:  filepath.Join(defaultDir, defaultTranscriptDir),
-			DefaultAnalyzeDir:     filepath.Join(defaultDir, defaultAnalyzeDir),
-			DefaultAnalyzeCallDir: filepath.Join(defaultDir, defaultAnalyzeCallDir),
-			ChunksDir:             filepath.Join(defaultDir, defaultChunksDir),
+			ChunkSeconds:        defaultChunksSeconds,
+			ChunkSilenceWindow:  getEnvInt("CHUNK_SILENCE_WINDOW", defaultChunkSilenceWindow),
+			SilenceThresholdDB:  getEnvInt("SILENCE_THRESHOLD_DB", defaultSilenceThresholdDB),
+			SilenceMinDuration:  getEnvFloat("SILENCE_MIN_DURATION", defaultSilenceMinDuration),
+			DropSilenceSeconds:  getEnvFloat("DROP_SILENCE_SECONDS", 0),
+			ChunkOverlapSeconds: getEnvFloat("CHUNK_OVERLAP_SECONDS", defaultChunkOverlapSeconds),
+			Diarize:             getEnvBool("DIARIZE", true),
 		},
+		LocalConfig: newLocalConfig(defaultDir),
 		DBConfig: DBConfig{
 			Path: filepath.Join(defaultDir, "local.db"),
 		},
 		AudioConfig: AudioConfig{
-			AudioSampleRate: defaultAudioSampleRate,
-			AudioChannels:   defaultAudioChannels,
-			AudioBitrate:    defaultAudioBitrate,
+			AudioSampleRate:            defaultAudioSampleRate,
+			AudioChannels:              defaultAudioChannels,
+			AudioBitrate:               defaultAudioBitrate,
+			AudioSeparateTracks:        getEnvBool("AUDIO_SEPARATE_TRACKS", true),
+			AudioLoopbackDevicePattern: getEnv("AUDIO_LOOPBACK_DEVICE_PATTERN", defaultLoopbackDevicePattern()),
 		},
 	}
 
+	// OpenAI-compatible endpoints are configured through env in every environment
+	if err := envconfig.Process(context.Background(), &cfg.GPTEndpoints); err != nil {
+		log.Printf("[I] Error parsing GPT endpoints env vars: %v\n", err)
+		return nil
+	}
+	// so are the rate and spending limits
+	if err := envconfig.Process(context.Background(), &cfg.LimitsConfig); err != nil {
+		log.Printf("[I] Error parsing limits env vars: %v\n", err)
+		return nil
+	}
+	if cfg.GPTRateLimits, err = parseRateLimits(cfg.GPTModelRateLimits); err != nil {
+		log.Printf("[I] Error parsing rate limits: %v\n", err)
+		return nil
+	}
+	if cfg.AnalysisLanguage, err = models.ParseLanguage(string(cfg.AnalysisLanguage)); err != nil {
+		log.Printf("[I] Error parsing analysis language: %v\n", err)
+		return nil
+	}
+
+	if err = validateRecordMode(cfg.GPTRecordMode); err != nil {
+		log.Printf("[I] Error parsing record mode: %v\n", err)
+		return nil
+	}
+
+	if cfg.GPTPrices, err = loadPrices(cfg.GPTPricesFile); err != nil {
+		log.Printf("[I] Error loading model prices: %v\n", err)
+		return nil
+	}
+
+	createLocalDirs(cfg)
+
+	log.Printf("[I] DBPath: %s\n", cfg.DBConfig.Path)
+
+	return cfg
+}
+
+// newLocalConfig builds the working directories layout rooted at defaultDir
+func newLocalConfig(defaultDir string) LocalConfig {
+	return LocalConfig{
+		DefaultDir:            defaultDir,
+		DefaultTranscriptDir:  filepath.Join(defaultDir, defaultTranscriptDir),
+		DefaultAnalyzeDir:     filepath.Join(defaultDir, defaultAnalyzeDir),
+		DefaultAnalyzeCallDir: filepath.Join(defaultDir, defaultAnalyzeCallDir),
+		RecordingDir:          filepath.Join(defaultDir, defaultAnalyzeCallDir, defaultRecordingDir),
+		ChunksDir:             filepath.Join(defaultDir, defaultChunksDir),
+	}
+}
+
+func createLocalDirs(cfg *Config) {
 	if err := os.Mkdir(cfg.DefaultTranscriptDir, os.ModePerm); err != nil {
 		if !os.IsExist(err) {
 			fmt.Printf("Failed to create default transcript directory: %s\n", err)
@@ -165,8 +381,172 @@ func ParseConfig() *Config {
 			fmt.Printf("Failed to create default analyze calls directory: %s\n", err)
 		}
 	}
+	if err := os.Mkdir(cfg.RecordingDir, os.ModePerm); err != nil {
+		if !os.IsExist(err) {
+			fmt.Printf("Failed to create recording directory: %s\n", err)
+		}
+	}
+}
 
-	fmt.Printf("DBPath: %s\n", cfg.DBConfig.Path)
+// defaultLoopbackDevicePattern returns the names of the loopback devices usual for the platform
+func defaultLoopbackDevicePattern() string {
+	if runtime.GOOS == "linux" {
+		return loopbackDevicePatternLinux
+	}
+	return loopbackDevicePatternDarwin
+}
 
-	return cfg
+func getEnv(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
+
+// getEnvBool returns the boolean value of the env variable, the fallback when it is not set or invalid
+func getEnvBool(key string, fallback bool) bool {
+	value, ok := os.LookupEnv(key)
+	if !ok || value == "" {
+		return fallback
+	}
+
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		log.Printf("[I] Invalid %s %q, using %v\n", key, value, fallback)
+		return fallback
+	}
+	return parsed
+}
+
+// getEnvInt returns the integer value of the env variable, the fallback when it is not set or invalid
+func getEnvInt(key string, fallback int) int {
+	value, ok := os.LookupEnv(key)
+	if !ok || value == "" {
+		return fallback
+	}
+
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		log.Printf("[I] Invalid %s %q, using %v\n", key, value, fallback)
+		return fallback
+	}
+	return parsed
+}
+
+// getEnvFloat returns the float value of the env variable, the fallback when it is not set or invalid
+func getEnvFloat(key string, fallback float64) float64 {
+	value, ok := os.LookupEnv(key)
+	if !ok || value == "" {
+		return fallback
+	}
+
+	parsed, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		log.Printf("[I] Invalid %s %q, using %v\n", key, value, fallback)
+		return fallback
+	}
+	return parsed
+}
+
+// getEnvDuration returns the duration value of the env variable, e.g. 2s, the fallback when it is not set or invalid
+func getEnvDuration(key string, fallback time.Duration) time.Duration {
+	value, ok := os.LookupEnv(key)
+	if !ok || value == "" {
+		return fallback
+	}
+
+	parsed, err := time.ParseDuration(value)
+	if err != nil {
+		log.Printf("[I] Invalid %s %q, using %v\n", key, value, fallback)
+		return fallback
+	}
+	return parsed
+}
+
+// validateRecordMode checks the GPT_RECORD_MODE value
+func validateRecordMode(mode string) error {
+	switch mode {
+	case RecordModeOff, RecordModeRecord, RecordModeReplay:
+		return nil
+	default:
+		return fmt.Errorf("unsupported record mode %q, use off, record or replay", mode)
+	}
+}
+
+// Replays reports whether the API requests are served from the recorded responses instead of the API
+func (c *GPTConfig) Replays() bool {
+	return c.GPTRecordMode == RecordModeReplay
+}
+
+// ResolveLanguage returns the language of the work: the requested one, the configured one when it is auto,
+// or else the one detected from the text
+func (c *GPTConfig) ResolveLanguage(lang models.Language, text string) models.Language {
+	if lang.IsAuto() {
+		lang = c.AnalysisLanguage
+	}
+	return lang.Resolve(text)
+}
+
+// ChunkTranscribeModel returns the model the chunks are transcribed with: GPTTranscribeModel,
+// or GPTSegmentsModel when only that one returns segment timestamps
+func (c *GPTConfig) ChunkTranscribeModel() string {
+	if SupportsSegments(c.GPTTranscribeModel) || c.GPTSegmentsModel == "" || c.GPTSegmentsModel == "off" {
+		return c.GPTTranscribeModel
+	}
+	return c.GPTSegmentsModel
+}
+
+// SupportsSegments reports whether the transcription model returns segment timestamps.
+// Only whisper models support the verbose_json format, gpt-4o transcription models accept json only.
+func SupportsSegments(model string) bool {
+	return strings.Contains(strings.ToLower(model), "whisper")
+}
+
+// TranscribeEndpoint returns the endpoint used for audio transcription
+func (c *GPTConfig) TranscribeEndpoint() GPTEndpoint {
+	return c.GPTTranscribeEndpoint.Merge(c.OpenAIEndpoint)
+}
+
+// ClassifyQuestionsEndpoint returns the endpoint used for interview and call analysis
+func (c *GPTConfig) ClassifyQuestionsEndpoint() GPTEndpoint {
+	return c.GPTClassifyQuestionsEndpoint.Merge(c.OpenAIEndpoint)
+}
+
+// GenerateQuestionsEndpoint returns the endpoint used for mock interview questions
+func (c *GPTConfig) GenerateQuestionsEndpoint() GPTEndpoint {
+	return c.GPTGenerateQuestionsEndpoint.Merge(c.OpenAIEndpoint)
+}
+
+// UsesCustomEndpoints reports whether any task is sent to a server other than the public OpenAI API
+func (c *GPTConfig) UsesCustomEndpoints() bool {
+	return c.TranscribeEndpoint().BaseURL != "" ||
+		c.ClassifyQuestionsEndpoint().BaseURL != "" ||
+		c.GenerateQuestionsEndpoint().BaseURL != ""
+}
+
+// Merge returns the endpoint with empty fields filled from base
+func (e GPTEndpoint) Merge(base GPTEndpoint) GPTEndpoint {
+	if e.BaseURL == "" {
+		e.BaseURL = base.BaseURL
+	}
+	if e.APIKey == "" {
+		e.APIKey = base.APIKey
+	}
+	if e.Organization == "" {
+		e.Organization = base.Organization
+	}
+	if e.Project == "" {
+		e.Project = base.Project
+	}
+
+	headers := make(map[string]string, len(base.Headers)+len(e.Headers))
+	for k, v := range base.Headers {
+		headers[k] = v
+	}
+	for k, v := range e.Headers {
+		headers[k] = v
+	}
+	e.Headers = headers
+
+	return e
 }
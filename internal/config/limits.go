@@ -0,0 +1,71 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type (
+	// LimitsConfig keeps the API requests within the rate limits of the account and the spending within a budget.
+	// A zero limit is no limit.
+	LimitsConfig struct {
+		// GPTRequestsPerMinute limits the requests sent to each model
+		GPTRequestsPerMinute int `env:"GPT_REQUESTS_PER_MINUTE, default=0"`
+		// GPTTokensPerMinute limits the prompt and completion tokens sent to each model
+		GPTTokensPerMinute int `env:"GPT_TOKENS_PER_MINUTE, default=0"`
+		// GPTModelRateLimits overrides the limits of single models as requests/tokens per minute, e.g. gpt-4.1-mini:500/200000
+		GPTModelRateLimits map[string]string `env:"GPT_MODEL_RATE_LIMITS"`
+		// GPTRateLimits are the parsed GPTModelRateLimits, by model
+		GPTRateLimits map[string]RateLimit
+		// SpendingMonthlyLimit is the most the requests of a calendar month may cost, in USD
+		SpendingMonthlyLimit float64 `env:"SPENDING_MONTHLY_LIMIT, default=0"`
+		// SpendingJobLimit is the most the requests of a processing job may cost, in USD
+		SpendingJobLimit float64 `env:"SPENDING_JOB_LIMIT, default=0"`
+	}
+
+	// RateLimit is how many requests and tokens a minute may be sent to a model
+	RateLimit struct {
+		Requests int
+		Tokens   int
+	}
+)
+
+// parseRateLimits parses the model limits written as requests/tokens
+func parseRateLimits(limits map[string]string) (map[string]RateLimit, error) {
+	parsed := make(map[string]RateLimit, len(limits))
+	for model, limit := range limits {
+		requests, tokens, ok := strings.Cut(limit, "/")
+		if !ok {
+			return nil, fmt.Errorf("rate limit of %s must be requests/tokens, got %q", model, limit)
+		}
+
+		var (
+			rl  RateLimit
+			err error
+		)
+		if rl.Requests, err = strconv.Atoi(strings.TrimSpace(requests)); err != nil || rl.Requests < 0 {
+			return nil, fmt.Errorf("invalid requests per minute of %s: %q", model, requests)
+		}
+		if rl.Tokens, err = strconv.Atoi(strings.TrimSpace(tokens)); err != nil || rl.Tokens < 0 {
+			return nil, fmt.Errorf("invalid tokens per minute of %s: %q", model, tokens)
+		}
+		parsed[model] = rl
+	}
+
+	return parsed, nil
+}
+
+// RateLimit returns the limits of the model
+func (c *LimitsConfig) RateLimit(model string) RateLimit {
+	if limit, ok := c.GPTRateLimits[model]; ok {
+		return limit
+	}
+
+	return RateLimit{Requests: c.GPTRequestsPerMinute, Tokens: c.GPTTokensPerMinute}
+}
+
+// Unlimited reports whether no limit is set
+func (l RateLimit) Unlimited() bool {
+	return l.Requests <= 0 && l.Tokens <= 0
+}
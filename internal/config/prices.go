@@ -0,0 +1,95 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ModelPrice is what a model costs in USD: per million prompt and completion tokens,
+// or per minute of audio for transcription models
+type ModelPrice struct {
+	Input       float64 `json:"input,omitempty"`
+	Output      float64 `json:"output,omitempty"`
+	AudioMinute float64 `json:"audio_minute,omitempty"`
+}
+
+// defaultPrices are the list prices of the models used by default, GPT_PRICES_FILE overrides and extends them
+var defaultPrices = map[string]ModelPrice{
+	"whisper-1":              {AudioMinute: 0.006},
+	"gpt-4o-transcribe":      {Input: 6, Output: 10, AudioMinute: 0.006},
+	"gpt-4o-mini-transcribe": {Input: 3, Output: 5, AudioMinute: 0.003},
+	"o3":                     {Input: 2, Output: 8},
+	"o4-mini":                {Input: 1.1, Output: 4.4},
+	"gpt-4.1":                {Input: 2, Output: 8},
+	"gpt-4.1-mini":           {Input: 0.4, Output: 1.6},
+	"gpt-4.1-nano":           {Input: 0.1, Output: 0.4},
+	"gpt-4o":                 {Input: 2.5, Output: 10},
+	"gpt-4o-mini":            {Input: 0.15, Output: 0.6},
+}
+
+// loadPrices returns the default price table with the prices of the JSON file on top,
+// a missing file keeps the defaults
+func loadPrices(path string) (map[string]ModelPrice, error) {
+	prices := make(map[string]ModelPrice, len(defaultPrices))
+	for model, price := range defaultPrices {
+		prices[model] = price
+	}
+
+	if path == "" {
+		return prices, nil
+	}
+
+	body, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return prices, nil
+		}
+		return nil, fmt.Errorf("read prices file: %w", err)
+	}
+
+	var custom map[string]ModelPrice
+	if err = json.Unmarshal(body, &custom); err != nil {
+		return nil, fmt.Errorf("parse prices file %s: %w", path, err)
+	}
+	for model, price := range custom {
+		prices[model] = price
+	}
+
+	return prices, nil
+}
+
+// Price returns the price of the model. Dated snapshots like gpt-4.1-2025-04-14 use the price of
+// the longest model name they start with.
+func (c *GPTConfig) Price(model string) (ModelPrice, bool) {
+	if price, ok := c.GPTPrices[model]; ok {
+		return price, true
+	}
+
+	var (
+		best  ModelPrice
+		found string
+	)
+	for name, price := range c.GPTPrices {
+		if strings.HasPrefix(model, name+"-") && len(name) > len(found) {
+			best, found = price, name
+		}
+	}
+
+	return best, found != ""
+}
+
+// Cost estimates the cost of a request in USD, 0 for models missing from the price table.
+// Models priced per audio minute are charged for the audio only when its duration is known.
+func (c *GPTConfig) Cost(model string, audioSeconds float64, promptTokens, completionTokens int64) float64 {
+	price, ok := c.Price(model)
+	if !ok {
+		return 0
+	}
+	if price.AudioMinute > 0 && audioSeconds > 0 {
+		return price.AudioMinute * audioSeconds / 60
+	}
+
+	return (price.Input*float64(promptTokens) + price.Output*float64(completionTokens)) / 1e6
+}
@@ -0,0 +1,61 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/mrbelka12000/interview_parser/internal/models"
+)
+
+// handleGetInterviewAnalytics retrieves analytics for a specific interview
+func (s *Server) handleGetInterviewAnalytics(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	analytics, err := s.service.GetInterviewAnalytics(id)
+	if err != nil {
+		writeError(w, http.StatusNotFound, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, analytics)
+}
+
+// handleGetAllInterviewAnalytics retrieves all interview analytics with optional date filters
+func (s *Server) handleGetAllInterviewAnalytics(w http.ResponseWriter, r *http.Request) {
+	filters, err := dateFilters(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	analytics, err := s.service.GetAllInterviewAnalytics(filters)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	if analytics == nil {
+		analytics = []models.InterviewAnalytics{}
+	}
+
+	writeJSON(w, http.StatusOK, analytics)
+}
+
+// handleGetGlobalAnalytics calculates aggregated statistics across all interviews
+func (s *Server) handleGetGlobalAnalytics(w http.ResponseWriter, r *http.Request) {
+	filters, err := dateFilters(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	analytics, err := s.service.GetGlobalAnalytics(filters)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, analytics)
+}
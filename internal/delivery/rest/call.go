@@ -0,0 +1,173 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/mrbelka12000/interview_parser/internal/models"
+)
+
+type (
+	// updateCallRequest represents the body of PUT /calls/{id}
+	updateCallRequest struct {
+		Transcript string          `json:"transcript"`
+		Analysis   json.RawMessage `json:"analysis"`
+	}
+
+	// updateCallAnalysisRequest represents the body of PUT /calls/{id}/analysis
+	updateCallAnalysisRequest struct {
+		Analysis json.RawMessage `json:"analysis"`
+	}
+)
+
+// handleGetAllCalls retrieves calls either by date range or with limit/offset pagination
+func (s *Server) handleGetAllCalls(w http.ResponseWriter, r *http.Request) {
+	var (
+		calls []models.Call
+		err   error
+	)
+
+	filters, err := dateFilters(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if filters.DateFrom != nil || filters.DateTo != nil {
+		dateFrom, dateTo := time.Time{}, time.Now()
+		if filters.DateFrom != nil {
+			dateFrom = *filters.DateFrom
+		}
+		if filters.DateTo != nil {
+			dateTo = *filters.DateTo
+		}
+
+		calls, err = s.service.GetCallsByDateRange(dateFrom, dateTo)
+	} else {
+		limit, lerr := queryInt(r, "limit")
+		offset, oerr := queryInt(r, "offset")
+		if err = errors.Join(lerr, oerr); err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		calls, err = s.service.GetAllCalls(limit, offset)
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	if calls == nil {
+		calls = []models.Call{}
+	}
+
+	writeJSON(w, http.StatusOK, calls)
+}
+
+// handleSaveCall creates a new call with transcript and optional analysis
+func (s *Server) handleSaveCall(w http.ResponseWriter, r *http.Request) {
+	var call models.Call
+	if err := decodeJSON(r, &call); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	call.ID = 0
+
+	created, err := s.service.SaveCall(&call)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, created)
+}
+
+// handleGetCall retrieves a specific call by ID
+func (s *Server) handleGetCall(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	call, err := s.service.GetCall(id)
+	if err != nil {
+		writeError(w, http.StatusNotFound, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, call)
+}
+
+// handleUpdateCall updates transcript and analysis of an existing call
+func (s *Server) handleUpdateCall(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	var req updateCallRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	var analysis interface{}
+	if len(req.Analysis) > 0 {
+		analysis = req.Analysis
+	}
+
+	call, err := s.service.UpdateCall(id, req.Transcript, analysis)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, call)
+}
+
+// handleUpdateCallAnalysis updates only the analysis field of a call
+func (s *Server) handleUpdateCallAnalysis(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	var req updateCallAnalysisRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	var analysis interface{}
+	if len(req.Analysis) > 0 {
+		analysis = req.Analysis
+	}
+
+	if err := s.service.UpdateCallAnalysis(id, analysis); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleDeleteCall deletes a call by ID
+func (s *Server) handleDeleteCall(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := s.service.DeleteCall(id); err != nil {
+		writeError(w, http.StatusNotFound, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
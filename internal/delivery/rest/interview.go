@@ -0,0 +1,188 @@
+package rest
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/mrbelka12000/interview_parser/internal/client"
+	"github.com/mrbelka12000/interview_parser/internal/models"
+	"github.com/mrbelka12000/interview_parser/internal/pipeline"
+)
+
+// updateInterviewRequest represents the body of PUT /interviews/{id}
+type updateInterviewRequest struct {
+	QA []models.QuestionAnswer `json:"qa"`
+}
+
+// handleGetAllInterviews retrieves all interviews with optional date filters
+func (s *Server) handleGetAllInterviews(w http.ResponseWriter, r *http.Request) {
+	filters, err := dateFilters(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	interviews, err := s.service.GetAllInterviews(filters)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	if interviews == nil {
+		interviews = []models.AnalyzeInterviewWithQA{}
+	}
+
+	writeJSON(w, http.StatusOK, interviews)
+}
+
+// handleSaveInterview creates a new interview with its question answers
+func (s *Server) handleSaveInterview(w http.ResponseWriter, r *http.Request) {
+	var interview models.AnalyzeInterviewWithQA
+	if err := decodeJSON(r, &interview); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	interview.ID = 0
+
+	if err := s.service.SaveInterview(&interview); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, interview)
+}
+
+// handleGetInterview retrieves a specific interview by ID
+func (s *Server) handleGetInterview(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	interview, err := s.service.GetInterview(id)
+	if err != nil {
+		writeError(w, http.StatusNotFound, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, interview)
+}
+
+// handleUpdateInterview replaces the question answers of an existing interview
+func (s *Server) handleUpdateInterview(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	var req updateInterviewRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	existing, err := s.service.GetInterview(id)
+	if err != nil {
+		writeError(w, http.StatusNotFound, err)
+		return
+	}
+
+	interview := &models.AnalyzeInterview{
+		ID:        existing.ID,
+		CreatedAt: existing.CreatedAt,
+		UpdatedAt: existing.UpdatedAt,
+	}
+	if err := s.service.UpdateInterview(interview, req.QA); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	updated, err := s.service.GetInterview(id)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, updated)
+}
+
+// handleDeleteInterview deletes an interview and its question answers
+func (s *Server) handleDeleteInterview(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := s.service.DeleteInterview(id); err != nil {
+		writeError(w, http.StatusNotFound, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleReanalyzeInterview analyzes the stored transcript of an interview again, the options in the body are optional
+func (s *Server) handleReanalyzeInterview(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	var opts pipeline.ReanalyzeOptions
+	if err := decodeJSON(r, &opts); err != nil && !errors.Is(err, io.EOF) {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if _, err := models.ParseLanguage(string(opts.Language)); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if _, err := s.service.GetInterview(id); err != nil {
+		writeError(w, http.StatusNotFound, err)
+		return
+	}
+
+	if !s.hasAPIKey() {
+		writeError(w, http.StatusPreconditionFailed, errors.New("no API key provided"))
+		return
+	}
+
+	interview, err := s.pipeline.ReanalyzeInterview(r.Context(), id, opts)
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, pipeline.ErrNoTranscript):
+			status = http.StatusConflict
+		case errors.Is(err, pipeline.ErrUnknownPromptVersion):
+			status = http.StatusBadRequest
+		case errors.Is(err, client.ErrBudgetExceeded):
+			status = http.StatusPaymentRequired
+		}
+		writeError(w, status, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, interview)
+}
+
+// handleGetInterviewAnalyses retrieves the analysis versions of an interview, oldest first
+func (s *Server) handleGetInterviewAnalyses(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	analyses, err := s.service.GetInterviewAnalyses(id)
+	if err != nil {
+		writeError(w, http.StatusNotFound, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, analyses)
+}
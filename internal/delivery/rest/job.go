@@ -0,0 +1,130 @@
+package rest
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mrbelka12000/interview_parser/internal/client"
+	"github.com/mrbelka12000/interview_parser/internal/models"
+	"github.com/mrbelka12000/interview_parser/internal/pipeline"
+)
+
+// uploadPrefix starts the names of uploaded media files, they are removed once their job is done
+const uploadPrefix = "upload_"
+
+func (s *Server) handleGetJobs(w http.ResponseWriter, r *http.Request) {
+	limit, err := queryInt(r, "limit")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	jobs, err := s.service.GetJobs(limit)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, jobs)
+}
+
+func (s *Server) handleGetJob(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	job, err := s.service.GetJob(id)
+	if err != nil {
+		writeError(w, http.StatusNotFound, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, job)
+}
+
+// handleRetryJob runs a failed, cancelled, paused or interrupted job again in the background,
+// continuing from its last completed chunk or batch
+func (s *Server) handleRetryJob(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if !s.hasAPIKey() {
+		writeError(w, http.StatusPreconditionFailed, errors.New("no API key provided"))
+		return
+	}
+
+	job, err := s.pipeline.RunJobInBackground(s.ctx, id, s.jobFinished)
+	if err != nil {
+		if job == nil {
+			writeError(w, http.StatusNotFound, err)
+			return
+		}
+		writeError(w, http.StatusConflict, fmt.Errorf("job %d: %w", job.ID, err))
+		return
+	}
+
+	writeJSON(w, http.StatusAccepted, jobAcceptedResponse{JobID: job.ID})
+}
+
+// handleCancelJob stops a running job and marks it cancelled, so it is not resumed anymore
+func (s *Server) handleCancelJob(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	job, err := s.pipeline.CancelJob(id)
+	if err != nil {
+		writeJobError(w, job, err)
+		return
+	}
+	s.jobFinished(job, pipeline.ErrJobCancelled)
+
+	writeJSON(w, http.StatusOK, job)
+}
+
+// resumeJobs continues the jobs interrupted when the server was stopped
+func (s *Server) resumeJobs() {
+	s.pipeline.ResumeJobs(s.ctx, s.jobFinished)
+}
+
+// jobFinished removes the uploaded media file of a job once it will not run again,
+// failed and partial jobs keep it for a retry
+func (s *Server) jobFinished(job *models.Job, err error) {
+	if job == nil || !strings.HasPrefix(filepath.Base(job.FilePath), uploadPrefix) {
+		return
+	}
+	if err != nil && !errors.Is(err, pipeline.ErrJobCancelled) {
+		return
+	}
+
+	os.Remove(job.FilePath)
+}
+
+// writeJobError reports a job that did not complete; its id is included so it can be retried
+func writeJobError(w http.ResponseWriter, job *models.Job, err error) {
+	if job == nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, client.ErrBudgetExceeded):
+		status = http.StatusPaymentRequired
+	case job.Status == models.JobStatusCompleted || job.Status == models.JobStatusCancelled:
+		status = http.StatusConflict
+	}
+
+	writeError(w, status, fmt.Errorf("job %d: %w", job.ID, err))
+}
@@ -0,0 +1,91 @@
+package rest
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/mrbelka12000/interview_parser/internal/client"
+	"github.com/mrbelka12000/interview_parser/internal/repo/postgres"
+	"github.com/mrbelka12000/interview_parser/internal/repo/sqlite"
+)
+
+type (
+	// apiKeyRequest represents the body of PUT /api-key
+	apiKeyRequest struct {
+		APIKey string `json:"api_key"`
+	}
+
+	// apiKeyResponse never exposes the full key, only a masked version of it
+	apiKeyResponse struct {
+		Configured bool   `json:"configured"`
+		APIKey     string `json:"api_key,omitempty"`
+	}
+)
+
+// handleGetAPIKey reports whether an OpenAI API key is stored
+func (s *Server) handleGetAPIKey(w http.ResponseWriter, _ *http.Request) {
+	apiKey, err := s.service.GetAPIKey()
+	if err != nil {
+		if errors.Is(err, postgres.ErrNoKey) || errors.Is(err, sqlite.ErrNoKey) {
+			writeJSON(w, http.StatusOK, apiKeyResponse{Configured: false})
+			return
+		}
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, apiKeyResponse{
+		Configured: apiKey != "",
+		APIKey:     maskAPIKey(apiKey),
+	})
+}
+
+// handleSaveAPIKey validates and stores a new OpenAI API key
+func (s *Server) handleSaveAPIKey(w http.ResponseWriter, r *http.Request) {
+	var req apiKeyRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if req.APIKey == "" {
+		writeError(w, http.StatusBadRequest, errors.New("API key cannot be empty"))
+		return
+	}
+
+	aiClient := client.NewProvider(s.cfg, req.APIKey, s.service)
+	if err := aiClient.IsValidAPIKeysProvided(); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := s.service.InsertAPIKey(req.APIKey); err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	s.setClient(aiClient)
+
+	writeJSON(w, http.StatusOK, apiKeyResponse{
+		Configured: true,
+		APIKey:     maskAPIKey(req.APIKey),
+	})
+}
+
+// handleDeleteAPIKey removes the stored OpenAI API key
+func (s *Server) handleDeleteAPIKey(w http.ResponseWriter, _ *http.Request) {
+	if err := s.service.DeleteAPIKey(); err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	s.setClient(client.NewProvider(s.cfg, "", s.service))
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func maskAPIKey(apiKey string) string {
+	if len(apiKey) <= 8 {
+		return strings.Repeat("*", len(apiKey))
+	}
+	return apiKey[:3] + strings.Repeat("*", len(apiKey)-7) + apiKey[len(apiKey)-4:]
+}
@@ -0,0 +1,129 @@
+package rest
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/mrbelka12000/interview_parser/internal/client"
+	"github.com/mrbelka12000/interview_parser/internal/models"
+)
+
+// jobAcceptedResponse identifies a job running in the background, its progress and result are read from /jobs/{id}
+type jobAcceptedResponse struct {
+	JobID          uint64 `json:"job_id"`
+	TranscriptPath string `json:"transcript_path,omitempty"`
+}
+
+// handleProcessInterview uploads a media file and starts a job transcribing it and analyzing it as an interview
+func (s *Server) handleProcessInterview(w http.ResponseWriter, r *http.Request) {
+	filePath, err := s.receiveUpload(w, r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if !s.hasAPIKey() {
+		os.Remove(filePath)
+		writeError(w, http.StatusPreconditionFailed, errors.New("no API key provided"))
+		return
+	}
+
+	// the language of the prompts and the transcription hint, empty means auto
+	lang, err := models.ParseLanguage(r.FormValue("language"))
+	if err != nil {
+		os.Remove(filePath)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	transcriptPath := filepath.Join(s.cfg.DefaultTranscriptDir, fmt.Sprintf("%s_transcript.txt", strings.TrimSuffix(filepath.Base(filePath), filepath.Ext(filePath))))
+
+	// the job outlives the request, the client polls it
+	job, err := s.pipeline.StartJobInBackground(s.ctx, models.JobTypeInterview, filePath, transcriptPath, lang, s.jobFinished)
+	if err != nil {
+		os.Remove(filePath)
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	writeJSON(w, http.StatusAccepted, jobAcceptedResponse{JobID: job.ID, TranscriptPath: transcriptPath})
+}
+
+// handleProcessCall uploads a media file and starts a job transcribing it and analyzing it as a call
+func (s *Server) handleProcessCall(w http.ResponseWriter, r *http.Request) {
+	filePath, err := s.receiveUpload(w, r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if !s.hasAPIKey() {
+		os.Remove(filePath)
+		writeError(w, http.StatusPreconditionFailed, errors.New("no API key provided"))
+		return
+	}
+
+	// the language of the prompts and the transcription hint, empty means auto
+	lang, err := models.ParseLanguage(r.FormValue("language"))
+	if err != nil {
+		os.Remove(filePath)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// the job outlives the request, the client polls it
+	job, err := s.pipeline.StartJobInBackground(s.ctx, models.JobTypeCall, filePath, "", lang, s.jobFinished)
+	if err != nil {
+		os.Remove(filePath)
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	writeJSON(w, http.StatusAccepted, jobAcceptedResponse{JobID: job.ID})
+}
+
+// receiveUpload stores the multipart "file" field in the data directory and returns its path
+func (s *Server) receiveUpload(w http.ResponseWriter, r *http.Request) (string, error) {
+	r.Body = http.MaxBytesReader(w, r.Body, s.cfg.HTTPMaxUploadSize<<20)
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		return "", fmt.Errorf("failed to read uploaded file: %w", err)
+	}
+	defer file.Close()
+
+	ext := strings.ToLower(filepath.Ext(header.Filename))
+	switch ext {
+	case ".mp3", ".wav", ".m4a", ".mp4", ".mov", ".avi":
+	default:
+		return "", fmt.Errorf("unsupported media format: %q", ext)
+	}
+
+	out, err := os.CreateTemp(s.cfg.DefaultDir, fmt.Sprintf("%s%s_*%s", uploadPrefix, time.Now().Format("20060102_150405"), ext))
+	if err != nil {
+		return "", fmt.Errorf("failed to create upload file: %w", err)
+	}
+	defer out.Close()
+
+	if _, err = io.Copy(out, file); err != nil {
+		os.Remove(out.Name())
+		return "", fmt.Errorf("failed to store uploaded file: %w", err)
+	}
+
+	return out.Name(), nil
+}
+
+func (s *Server) hasAPIKey() bool {
+	if !client.RequiresAPIKey(s.cfg) {
+		return true
+	}
+
+	apiKey, err := s.service.GetAPIKey()
+	return err == nil && apiKey != ""
+}
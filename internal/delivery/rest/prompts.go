@@ -0,0 +1,108 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/mrbelka12000/interview_parser/internal/client"
+	"github.com/mrbelka12000/interview_parser/internal/models"
+)
+
+type (
+	// promptRequest represents the body of POST /prompts/{name}/{language} and its preview
+	promptRequest struct {
+		Label string `json:"label"`
+		Body  string `json:"body"`
+	}
+
+	// promptVersionsResponse lists the versions of a prompt template, the built-in one is version 0
+	promptVersionsResponse struct {
+		Current  models.PromptTemplate   `json:"current"`
+		Versions []models.PromptTemplate `json:"versions"`
+	}
+)
+
+// handleGetPromptSpecs lists the editable prompt templates and their placeholders
+func (s *Server) handleGetPromptSpecs(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, client.PromptSpecs)
+}
+
+// handleGetPromptVersions returns the versions of a prompt template in a language, the latest one is current
+func (s *Server) handleGetPromptVersions(w http.ResponseWriter, r *http.Request) {
+	lang, err := client.PromptLanguage(r.PathValue("language"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if _, err := client.DefaultPrompt(r.PathValue("name"), lang); err != nil {
+		writeError(w, http.StatusNotFound, err)
+		return
+	}
+
+	versions, err := client.PromptVersions(s.service, r.PathValue("name"), lang)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, promptVersionsResponse{
+		Current:  versions[len(versions)-1],
+		Versions: versions,
+	})
+}
+
+// handleSavePromptTemplate saves the body as the next version of a prompt template
+func (s *Server) handleSavePromptTemplate(w http.ResponseWriter, r *http.Request) {
+	lang, err := client.PromptLanguage(r.PathValue("language"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	var req promptRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := client.ValidatePrompt(r.PathValue("name"), req.Body); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	prompt := &models.PromptTemplate{
+		Name:     r.PathValue("name"),
+		Language: lang,
+		Label:    req.Label,
+		Body:     req.Body,
+	}
+	if err := s.service.SavePromptTemplate(prompt); err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, prompt)
+}
+
+// handlePreviewPrompt renders a prompt template body with sample values, as it would be sent to the model
+func (s *Server) handlePreviewPrompt(w http.ResponseWriter, r *http.Request) {
+	lang, err := client.PromptLanguage(r.PathValue("language"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	var req promptRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	preview, err := client.PreviewPrompt(r.PathValue("name"), lang, req.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, preview)
+}
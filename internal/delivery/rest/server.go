@@ -0,0 +1,219 @@
+package rest
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mrbelka12000/interview_parser/internal/client"
+	"github.com/mrbelka12000/interview_parser/internal/config"
+	"github.com/mrbelka12000/interview_parser/internal/models"
+	"github.com/mrbelka12000/interview_parser/internal/parser"
+	"github.com/mrbelka12000/interview_parser/internal/pipeline"
+	"github.com/mrbelka12000/interview_parser/internal/repo"
+	"github.com/mrbelka12000/interview_parser/internal/service"
+)
+
+const (
+	apiPrefix = "/api/v1"
+
+	// minUploadRate is the slowest upload in bytes per second the read timeout leaves time for
+	minUploadRate = 1 << 20
+	// idleTimeout closes keep-alive connections without requests
+	idleTimeout = 2 * time.Minute
+)
+
+// Server exposes the service operations over a versioned JSON HTTP API
+type Server struct {
+	cfg      *config.Config
+	service  *service.Service
+	parser   *parser.Parser
+	pipeline *pipeline.Pipeline
+	// ctx lives as long as the server, jobs run on it rather than on the requests starting them
+	ctx  context.Context
+	stop context.CancelFunc
+}
+
+// NewServer creates a new HTTP server using the repositories selected by the config
+func NewServer(cfg *config.Config) *Server {
+	s := &Server{
+		cfg:     cfg,
+		service: service.New(repo.NewRepositories(cfg)),
+		parser:  parser.NewParser(cfg),
+	}
+	s.ctx, s.stop = context.WithCancel(context.Background())
+
+	apiKey, err := s.service.GetAPIKey()
+	if err != nil {
+		log.Printf("[I] No API key stored yet: %v\n", err)
+	}
+	s.pipeline = pipeline.New(cfg, s.parser, s.service, client.NewProvider(cfg, apiKey, s.service), func(jobID uint64, percentage int, stage, details string) {
+		log.Printf("[i] job %d: %d%% %s %s\n", jobID, percentage, stage, details)
+	})
+
+	return s
+}
+
+// Run starts listening on the configured HTTP port. The running jobs are paused when it stops.
+// It refuses to start without HTTP_AUTH_TOKEN, the API manages the stored API key.
+func (s *Server) Run() error {
+	defer s.stop()
+
+	if s.cfg.HTTPAuthToken == "" {
+		return errors.New("HTTP_AUTH_TOKEN is required to serve the HTTP API")
+	}
+
+	srv := &http.Server{
+		Addr:              fmt.Sprintf(":%v", s.cfg.HTTPServerPort),
+		Handler:           s.routes(),
+		ReadHeaderTimeout: 10 * time.Second,
+		// the largest upload has to arrive at minUploadRate at least
+		ReadTimeout: max(time.Minute, time.Duration(s.cfg.HTTPMaxUploadSize<<20/minUploadRate)*time.Second),
+		IdleTimeout: idleTimeout,
+	}
+
+	// jobs interrupted when the server was stopped continue in the background
+	go s.resumeJobs()
+
+	log.Printf("HTTP API server starting on port %d", s.cfg.HTTPServerPort)
+	return srv.ListenAndServe()
+}
+
+func (s *Server) routes() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET "+apiPrefix+"/health", s.handleHealth)
+
+	mux.HandleFunc("GET "+apiPrefix+"/api-key", s.handleGetAPIKey)
+	mux.HandleFunc("PUT "+apiPrefix+"/api-key", s.handleSaveAPIKey)
+	mux.HandleFunc("DELETE "+apiPrefix+"/api-key", s.handleDeleteAPIKey)
+
+	mux.HandleFunc("GET "+apiPrefix+"/interviews", s.handleGetAllInterviews)
+	mux.HandleFunc("POST "+apiPrefix+"/interviews", s.handleSaveInterview)
+	mux.HandleFunc("POST "+apiPrefix+"/interviews/process", s.handleProcessInterview)
+	mux.HandleFunc("GET "+apiPrefix+"/interviews/{id}", s.handleGetInterview)
+	mux.HandleFunc("PUT "+apiPrefix+"/interviews/{id}", s.handleUpdateInterview)
+	mux.HandleFunc("DELETE "+apiPrefix+"/interviews/{id}", s.handleDeleteInterview)
+	mux.HandleFunc("GET "+apiPrefix+"/interviews/{id}/analytics", s.handleGetInterviewAnalytics)
+	mux.HandleFunc("PUT "+apiPrefix+"/interviews/{id}/speakers", s.handleRenameInterviewSpeaker)
+	mux.HandleFunc("POST "+apiPrefix+"/interviews/{id}/reanalyze", s.handleReanalyzeInterview)
+	mux.HandleFunc("GET "+apiPrefix+"/interviews/{id}/analyses", s.handleGetInterviewAnalyses)
+
+	mux.HandleFunc("GET "+apiPrefix+"/calls", s.handleGetAllCalls)
+	mux.HandleFunc("POST "+apiPrefix+"/calls", s.handleSaveCall)
+	mux.HandleFunc("POST "+apiPrefix+"/calls/process", s.handleProcessCall)
+	mux.HandleFunc("GET "+apiPrefix+"/calls/{id}", s.handleGetCall)
+	mux.HandleFunc("PUT "+apiPrefix+"/calls/{id}", s.handleUpdateCall)
+	mux.HandleFunc("PUT "+apiPrefix+"/calls/{id}/analysis", s.handleUpdateCallAnalysis)
+	mux.HandleFunc("PUT "+apiPrefix+"/calls/{id}/speakers", s.handleRenameCallSpeaker)
+	mux.HandleFunc("DELETE "+apiPrefix+"/calls/{id}", s.handleDeleteCall)
+
+	mux.HandleFunc("GET "+apiPrefix+"/jobs", s.handleGetJobs)
+	mux.HandleFunc("GET "+apiPrefix+"/jobs/{id}", s.handleGetJob)
+	mux.HandleFunc("POST "+apiPrefix+"/jobs/{id}/retry", s.handleRetryJob)
+	mux.HandleFunc("POST "+apiPrefix+"/jobs/{id}/cancel", s.handleCancelJob)
+
+	mux.HandleFunc("GET "+apiPrefix+"/analytics/interviews", s.handleGetAllInterviewAnalytics)
+	mux.HandleFunc("GET "+apiPrefix+"/analytics/global", s.handleGetGlobalAnalytics)
+
+	mux.HandleFunc("GET "+apiPrefix+"/prompts", s.handleGetPromptSpecs)
+	mux.HandleFunc("GET "+apiPrefix+"/prompts/{name}/{language}", s.handleGetPromptVersions)
+	mux.HandleFunc("POST "+apiPrefix+"/prompts/{name}/{language}", s.handleSavePromptTemplate)
+	mux.HandleFunc("POST "+apiPrefix+"/prompts/{name}/{language}/preview", s.handlePreviewPrompt)
+
+	return s.withAuth(mux)
+}
+
+// withAuth requires the bearer token of HTTP_AUTH_TOKEN, no request passes without one configured
+func (s *Server) withAuth(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != apiPrefix+"/health" {
+			token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+			// compared in constant time, so the response time does not tell how much of the token matched
+			if s.cfg.HTTPAuthToken == "" || subtle.ConstantTimeCompare([]byte(token), []byte(s.cfg.HTTPAuthToken)) != 1 {
+				writeError(w, http.StatusUnauthorized, errors.New("unauthorized"))
+				return
+			}
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) handleHealth(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+func (s *Server) setClient(aiClient client.Provider) {
+	s.pipeline.SetAIClient(aiClient)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Printf("Error encoding response: %v", err)
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
+
+func decodeJSON(r *http.Request, v interface{}) error {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		return fmt.Errorf("invalid request body: %w", err)
+	}
+	return nil
+}
+
+func pathID(r *http.Request) (uint64, error) {
+	id, err := strconv.ParseUint(r.PathValue("id"), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid id: %s", r.PathValue("id"))
+	}
+	return id, nil
+}
+
+func queryInt(r *http.Request, key string) (int, error) {
+	value := r.URL.Query().Get(key)
+	if value == "" {
+		return 0, nil
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s: %s", key, value)
+	}
+	return n, nil
+}
+
+// dateFilters parses date_from/date_to query parameters in 2006-01-02 format
+func dateFilters(r *http.Request) (*models.GetInterviewsFilters, error) {
+	filters := &models.GetInterviewsFilters{}
+
+	if dateFrom := r.URL.Query().Get("date_from"); dateFrom != "" {
+		parsed, err := time.Parse("2006-01-02", dateFrom)
+		if err != nil {
+			return nil, fmt.Errorf("invalid date_from format: %w", err)
+		}
+		filters.DateFrom = &parsed
+	}
+
+	if dateTo := r.URL.Query().Get("date_to"); dateTo != "" {
+		parsed, err := time.Parse("2006-01-02", dateTo)
+		if err != nil {
+			return nil, fmt.Errorf("invalid date_to format: %w", err)
+		}
+		filters.DateTo = &parsed
+	}
+
+	return filters, nil
+}
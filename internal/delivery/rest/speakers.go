@@ -0,0 +1,67 @@
+package rest
+
+import (
+	"net/http"
+)
+
+// renameSpeakerRequest represents the body of PUT /interviews/{id}/speakers and PUT /calls/{id}/speakers
+type renameSpeakerRequest struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// handleRenameInterviewSpeaker renames a speaker in the transcript and question answers of an interview
+func (s *Server) handleRenameInterviewSpeaker(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	var req renameSpeakerRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if _, err := s.service.GetInterview(id); err != nil {
+		writeError(w, http.StatusNotFound, err)
+		return
+	}
+
+	interview, err := s.service.RenameInterviewSpeaker(id, req.From, req.To)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, interview)
+}
+
+// handleRenameCallSpeaker renames a speaker in the transcript of a call
+func (s *Server) handleRenameCallSpeaker(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	var req renameSpeakerRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if _, err := s.service.GetCall(id); err != nil {
+		writeError(w, http.StatusNotFound, err)
+		return
+	}
+
+	call, err := s.service.RenameCallSpeaker(id, req.From, req.To)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, call)
+}
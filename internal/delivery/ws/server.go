@@ -13,6 +13,7 @@ import (
 
 	"github.com/mrbelka12000/interview_parser/internal/client"
 	"github.com/mrbelka12000/interview_parser/internal/config"
+	"github.com/mrbelka12000/interview_parser/internal/models"
 )
 
 // MessageType represents the type of WebSocket message
@@ -44,6 +45,8 @@ type StartMessage struct {
 	Level          string `json:"level"`
 	Meta           string `json:"meta"`
 	QuestionsCount int    `json:"questions_count"`
+	// Language of the interview: ru, en, or auto or empty for the configured language
+	Language string `json:"language"`
 }
 
 // ResponseMessage represents a response (user answer or AI question)
@@ -73,7 +76,7 @@ type AnalyticsMessage struct {
 // InterviewSession manages a single mock interview session
 type InterviewSession struct {
 	conn           *websocket.Conn
-	aiClient       *client.Client
+	aiClient       client.QuestionGenerator
 	isActive       bool
 	currentIndex   int
 	questions      []client.GeneratedQuestion
@@ -84,6 +87,7 @@ type InterviewSession struct {
 	specialization string
 	level          string
 	meta           string
+	language       models.Language
 	startTime      time.Time
 	mutex          sync.RWMutex
 }
@@ -168,6 +172,11 @@ func (s *InterviewSession) handleStartMessage(msg WSMessage) {
 		s.sendError("Invalid start message format")
 		return
 	}
+	lang, err := models.ParseLanguage(startMsg.Language)
+	if err != nil {
+		s.sendError(err.Error())
+		return
+	}
 
 	s.mutex.Lock()
 	s.cv = startMsg.CV
@@ -175,6 +184,7 @@ func (s *InterviewSession) handleStartMessage(msg WSMessage) {
 	s.specialization = startMsg.Specialization
 	s.level = startMsg.Level
 	s.meta = startMsg.Meta
+	s.language = lang
 	s.startTime = time.Now()
 	s.mutex.Unlock()
 
@@ -208,6 +218,7 @@ func (s *InterviewSession) generateQuestions(startMsg StartMessage) {
 		Level:          startMsg.Level,
 		Meta:           startMsg.Meta,
 		QuestionsCount: startMsg.QuestionsCount,
+		Language:       s.language,
 	}
 
 	ctx, cancel := context.WithCancel(context.Background())
@@ -420,6 +431,7 @@ func (s *InterviewSession) generateFinalAnalytics() {
 		Meta:           s.meta,
 		Questions:      questions,
 		Answers:        s.answers,
+		Language:       s.language,
 	})
 	if err != nil {
 		s.sendError("Failed to analyze mock interview")
@@ -463,7 +475,7 @@ func (s *InterviewSession) sendError(message string) {
 	s.sendMessage(errorMsg)
 }
 
-func RunServer(cfg *config.Config, aiClient *client.Client) error {
+func RunServer(cfg *config.Config, aiClient client.QuestionGenerator) error {
 	// Initialize AI client for the global session
 
 	http.HandleFunc("/ws", func(w http.ResponseWriter, r *http.Request) {
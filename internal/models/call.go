@@ -7,10 +7,13 @@ import (
 
 type (
 	Call struct {
-		ID         uint64         `json:"id" gorm:"primaryKey" db:"id"`
-		Transcript string         `json:"transcript" gorm:"not null" db:"transcript"`
-		Analysis   json.RawMessage `json:"analysis" db:"analysis"`
-		CreatedAt  time.Time      `json:"created_at" gorm:"autoCreateTime" db:"created_at"`
-		UpdatedAt  time.Time      `json:"updated_at" gorm:"autoUpdateTime" db:"updated_at"`
+		ID         uint64             `json:"id" gorm:"primaryKey" db:"id"`
+		Transcript string             `json:"transcript" gorm:"not null" db:"transcript"`
+		Analysis   json.RawMessage    `json:"analysis" db:"analysis"`
+		Segments   TranscriptSegments `json:"segments,omitempty" gorm:"type:jsonb" db:"segments"`
+		// PromptVersion names the version of the prompt template the call was analyzed with
+		PromptVersion string    `json:"prompt_version,omitempty" db:"prompt_version"`
+		CreatedAt     time.Time `json:"created_at" gorm:"autoCreateTime" db:"created_at"`
+		UpdatedAt     time.Time `json:"updated_at" gorm:"autoUpdateTime" db:"updated_at"`
 	}
 )
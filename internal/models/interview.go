@@ -1,20 +1,31 @@
 package models
 
 import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
 	"time"
 )
 
 type (
 	AnalyzeInterview struct {
-		ID        uint64    `json:"id" gorm:"primaryKey" db:"id"`
-		CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime" db:"created_at"`
-		UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime" db:"updated_at"`
+		ID         uint64             `json:"id" gorm:"primaryKey" db:"id"`
+		Transcript string             `json:"transcript,omitempty" db:"transcript"`
+		Segments   TranscriptSegments `json:"segments,omitempty" gorm:"type:jsonb" db:"segments"`
+		// PromptVersion names the versions of the prompt templates the interview was analyzed with
+		PromptVersion string    `json:"prompt_version,omitempty" db:"prompt_version"`
+		CreatedAt     time.Time `json:"created_at" gorm:"autoCreateTime" db:"created_at"`
+		UpdatedAt     time.Time `json:"updated_at" gorm:"autoUpdateTime" db:"updated_at"`
 	}
 	AnalyzeInterviewWithQA struct {
-		ID        uint64           `json:"id" gorm:"primaryKey" db:"id"`
-		QA        []QuestionAnswer `json:"qa" gorm:"foreignKey:InterviewID" db:"qa"`
-		CreatedAt time.Time        `json:"created_at" gorm:"autoCreateTime" db:"created_at"`
-		UpdatedAt time.Time        `json:"updated_at" gorm:"autoUpdateTime" db:"updated_at"`
+		ID         uint64             `json:"id" gorm:"primaryKey" db:"id"`
+		QA         []QuestionAnswer   `json:"qa" gorm:"foreignKey:InterviewID" db:"qa"`
+		Transcript string             `json:"transcript,omitempty" db:"transcript"`
+		Segments   TranscriptSegments `json:"segments,omitempty" gorm:"type:jsonb" db:"segments"`
+		// PromptVersion names the versions of the prompt templates the interview was analyzed with
+		PromptVersion string    `json:"prompt_version,omitempty" db:"prompt_version"`
+		CreatedAt     time.Time `json:"created_at" gorm:"autoCreateTime" db:"created_at"`
+		UpdatedAt     time.Time `json:"updated_at" gorm:"autoUpdateTime" db:"updated_at"`
 	}
 	QuestionAnswer struct {
 		ID               uint64    `json:"id" gorm:"primaryKey" db:"id"`
@@ -23,13 +34,72 @@ type (
 		FullAnswer       string    `json:"full_answer" db:"full_answer"`
 		Accuracy         float64   `json:"accuracy" gorm:"not null" db:"accuracy"`
 		ReasonUnanswered string    `json:"reason_unanswered" db:"reason_unanswered"`
+		Questioner       string    `json:"questioner" db:"questioner"`
+		Answerer         string    `json:"answerer" db:"answerer"`
+		StartTime        *float64  `json:"start_time,omitempty" db:"start_time"`
+		EndTime          *float64  `json:"end_time,omitempty" db:"end_time"`
 		CreatedAt        time.Time `json:"created_at" gorm:"autoCreateTime" db:"created_at"`
 		UpdatedAt        time.Time `json:"updated_at" gorm:"autoUpdateTime" db:"updated_at"`
 	}
 
+	// QuestionAnswers is stored as a JSON column
+	QuestionAnswers []QuestionAnswer
+
+	// InterviewAnalysis is a version of the analysis of an interview transcript, kept for comparison.
+	// Version 1 is the analysis the interview was saved with and every re-analysis adds the next one,
+	// the latest version is the current analysis of the interview.
+	InterviewAnalysis struct {
+		ID          uint64 `json:"id" gorm:"primaryKey" db:"id"`
+		InterviewID uint64 `json:"interview_id" gorm:"not null;uniqueIndex:idx_interview_analyses_version" db:"interview_id"`
+		Version     int    `json:"version" gorm:"not null;uniqueIndex:idx_interview_analyses_version" db:"version"`
+		// Model is empty for analyses made before their model was recorded
+		Model         string          `json:"model,omitempty" db:"model"`
+		PromptVersion string          `json:"prompt_version,omitempty" db:"prompt_version"`
+		Language      Language        `json:"language,omitempty" db:"language"`
+		QA            QuestionAnswers `json:"qa" gorm:"type:jsonb" db:"qa"`
+		CreatedAt     time.Time       `json:"created_at" gorm:"autoCreateTime" db:"created_at"`
+	}
+
 	// GetInterviewsFilters represents filters for querying analytics
 	GetInterviewsFilters struct {
 		DateFrom *time.Time `json:"dateFrom,omitempty"`
 		DateTo   *time.Time `json:"dateTo,omitempty"`
 	}
 )
+
+// Value implements driver.Valuer
+func (q QuestionAnswers) Value() (driver.Value, error) {
+	if q == nil {
+		return nil, nil
+	}
+
+	body, err := json.Marshal(q)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal question answers: %w", err)
+	}
+
+	return string(body), nil
+}
+
+// Scan implements sql.Scanner
+func (q *QuestionAnswers) Scan(src any) error {
+	var body []byte
+	switch v := src.(type) {
+	case nil:
+		*q = nil
+		return nil
+	case []byte:
+		body = v
+	case string:
+		body = []byte(v)
+	default:
+		return fmt.Errorf("unsupported question answers type: %T", src)
+	}
+
+	if len(body) == 0 || string(body) == "null" {
+		*q = nil
+		return nil
+	}
+
+	return json.Unmarshal(body, q)
+}
@@ -0,0 +1,186 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+type (
+	// JobType is what a processing job makes of the media file
+	JobType string
+
+	// JobStatus is the state of a processing job
+	JobStatus string
+
+	// Job is the persisted state of processing a media file, so an interrupted job
+	// resumes from the last completed chunk or analysis batch. Language is auto until the transcript is detected.
+	Job struct {
+		ID             uint64         `json:"id" gorm:"primaryKey" db:"id"`
+		Type           JobType        `json:"type" gorm:"not null" db:"type"`
+		Status         JobStatus      `json:"status" gorm:"index;not null" db:"status"`
+		FilePath       string         `json:"file_path" gorm:"not null" db:"file_path"`
+		TranscriptPath string         `json:"transcript_path,omitempty" db:"transcript_path"`
+		Language       Language       `json:"language" gorm:"not null;default:auto" db:"language"`
+		Chunks         JobChunks      `json:"chunks" gorm:"type:jsonb" db:"chunks"`
+		Transcript     *JobTranscript `json:"transcript,omitempty" gorm:"type:jsonb" db:"transcript"`
+		Batches        JobBatches     `json:"batches" gorm:"type:jsonb" db:"batches"`
+		ResultID       uint64         `json:"result_id,omitempty" db:"result_id"`
+		Error          string         `json:"error,omitempty" db:"error"`
+		CreatedAt      time.Time      `json:"created_at" gorm:"autoCreateTime" db:"created_at"`
+		UpdatedAt      time.Time      `json:"updated_at" gorm:"autoUpdateTime" db:"updated_at"`
+	}
+
+	// JobChunk is a chunk of the media file with its transcript once it is transcribed,
+	// or the error of its last attempt
+	JobChunk struct {
+		Path       string     `json:"path"`
+		Start      float64    `json:"start"`
+		Duration   float64    `json:"duration"`
+		Overlap    float64    `json:"overlap,omitempty"`
+		Done       bool       `json:"done"`
+		Transcript Transcript `json:"transcript"`
+		Error      string     `json:"error,omitempty"`
+	}
+
+	// JobChunks is stored as a JSON column
+	JobChunks []JobChunk
+
+	// JobBatch is a part of the transcript analyzed in one request, with its result once it is analyzed,
+	// or the error of its last attempt
+	JobBatch struct {
+		Text  string           `json:"text"`
+		Done  bool             `json:"done"`
+		QA    []QuestionAnswer `json:"qa,omitempty"`
+		Error string           `json:"error,omitempty"`
+		// PromptVersion is the version of the prompt template the batch was analyzed with
+		PromptVersion string `json:"prompt_version,omitempty"`
+	}
+
+	// JobBatches is stored as a JSON column
+	JobBatches []JobBatch
+
+	// JobTranscript is the stitched transcript of a job, stored as a JSON column
+	JobTranscript Transcript
+
+	// JobFailure is a chunk or an analysis batch of a job that failed after all retries.
+	// Start and End are the position of a chunk in the recording, in seconds.
+	JobFailure struct {
+		Index int     `json:"index"`
+		Start float64 `json:"start,omitempty"`
+		End   float64 `json:"end,omitempty"`
+		Error string  `json:"error"`
+	}
+)
+
+const (
+	JobTypeInterview JobType = "interview"
+	JobTypeCall      JobType = "call"
+)
+
+const (
+	JobStatusPending   JobStatus = "pending"
+	JobStatusRunning   JobStatus = "running"
+	JobStatusCompleted JobStatus = "completed"
+	// JobStatusPartial is a completed job with failed chunks or batches, retrying it runs only those again
+	JobStatusPartial JobStatus = "partial"
+	// JobStatusPaused is a job stopped by the spending limits or interrupted, e.g. by closing the app,
+	// it continues from where it stopped when it is run again
+	JobStatusPaused    JobStatus = "paused"
+	JobStatusFailed    JobStatus = "failed"
+	JobStatusCancelled JobStatus = "cancelled"
+)
+
+// Finished reports whether the job will not run again unless it is retried
+func (s JobStatus) Finished() bool {
+	return s == JobStatusCompleted || s == JobStatusPartial || s == JobStatusFailed || s == JobStatusCancelled
+}
+
+// FailedChunks returns the chunks that failed to transcribe
+func (j *Job) FailedChunks() []JobFailure {
+	var failures []JobFailure
+	for i, chunk := range j.Chunks {
+		if !chunk.Done && chunk.Error != "" {
+			failures = append(failures, JobFailure{Index: i, Start: chunk.Start, End: chunk.Start + chunk.Duration, Error: chunk.Error})
+		}
+	}
+	return failures
+}
+
+// FailedBatches returns the transcript batches that failed to analyze
+func (j *Job) FailedBatches() []JobFailure {
+	var failures []JobFailure
+	for i, batch := range j.Batches {
+		if !batch.Done && batch.Error != "" {
+			failures = append(failures, JobFailure{Index: i, Error: batch.Error})
+		}
+	}
+	return failures
+}
+
+// Value implements driver.Valuer
+func (c JobChunks) Value() (driver.Value, error) {
+	return jsonValue(c, c == nil)
+}
+
+// Scan implements sql.Scanner
+func (c *JobChunks) Scan(src any) error {
+	*c = nil
+	return jsonScan(src, c)
+}
+
+// Value implements driver.Valuer
+func (b JobBatches) Value() (driver.Value, error) {
+	return jsonValue(b, b == nil)
+}
+
+// Scan implements sql.Scanner
+func (b *JobBatches) Scan(src any) error {
+	*b = nil
+	return jsonScan(src, b)
+}
+
+// Value implements driver.Valuer
+func (r *JobTranscript) Value() (driver.Value, error) {
+	return jsonValue(r, r == nil)
+}
+
+// Scan implements sql.Scanner
+func (r *JobTranscript) Scan(src any) error {
+	*r = JobTranscript{}
+	return jsonScan(src, r)
+}
+
+func jsonValue(v any, isNil bool) (driver.Value, error) {
+	if isNil {
+		return nil, nil
+	}
+
+	body, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal %T: %w", v, err)
+	}
+
+	return string(body), nil
+}
+
+func jsonScan(src any, dst any) error {
+	var body []byte
+	switch v := src.(type) {
+	case nil:
+		return nil
+	case []byte:
+		body = v
+	case string:
+		body = []byte(v)
+	default:
+		return fmt.Errorf("unsupported %T type: %T", dst, src)
+	}
+
+	if len(body) == 0 || string(body) == "null" {
+		return nil
+	}
+
+	return json.Unmarshal(body, dst)
+}
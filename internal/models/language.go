@@ -0,0 +1,61 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Language is the language of a conversation, it selects the analysis prompts and is the transcription hint
+type Language string
+
+const (
+	// LanguageAuto detects the language from the transcript
+	LanguageAuto Language = "auto"
+	LanguageRU   Language = "ru"
+	LanguageEN   Language = "en"
+)
+
+// ParseLanguage returns the language of the code, empty means auto
+func ParseLanguage(code string) (Language, error) {
+	switch lang := Language(strings.ToLower(strings.TrimSpace(code))); lang {
+	case "", LanguageAuto:
+		return LanguageAuto, nil
+	case LanguageRU, LanguageEN:
+		return lang, nil
+	default:
+		return "", fmt.Errorf("unsupported language %q, use auto, ru or en", code)
+	}
+}
+
+// IsAuto reports whether the language is left to detection
+func (l Language) IsAuto() bool {
+	return l == "" || l == LanguageAuto
+}
+
+// Resolve returns the language, detected from the text when it is auto
+func (l Language) Resolve(text string) Language {
+	if l.IsAuto() {
+		return DetectLanguage(text)
+	}
+	return l
+}
+
+// DetectLanguage tells Russian from English by the alphabet most letters of the text are written in.
+// Text without letters is taken as Russian, the language the app was made for.
+func DetectLanguage(text string) Language {
+	var cyrillic, latin int
+	for _, r := range text {
+		switch {
+		case unicode.Is(unicode.Cyrillic, r):
+			cyrillic++
+		case unicode.Is(unicode.Latin, r):
+			latin++
+		}
+	}
+
+	if latin > cyrillic {
+		return LanguageEN
+	}
+	return LanguageRU
+}
@@ -0,0 +1,29 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+type (
+	// PromptTemplate is a version of a prompt edited by the user. Versions of a name and language are numbered
+	// from 1, the latest one is used; version 0 is the built-in prompt.
+	PromptTemplate struct {
+		ID       uint64   `json:"id" gorm:"primaryKey" db:"id"`
+		Name     string   `json:"name" gorm:"not null;uniqueIndex:idx_prompt_templates_version" db:"name"`
+		Language Language `json:"language" gorm:"not null;uniqueIndex:idx_prompt_templates_version" db:"language"`
+		Version  int      `json:"version" gorm:"not null;uniqueIndex:idx_prompt_templates_version" db:"version"`
+		// Label is an optional name of the version, e.g. "stricter accuracy"
+		Label     string    `json:"label,omitempty" db:"label"`
+		Body      string    `json:"body" gorm:"not null" db:"body"`
+		CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime" db:"created_at"`
+	}
+)
+
+// Ref identifies the version of the template in the results it produced, e.g. "analyze_call/en@v2"
+func (t *PromptTemplate) Ref() string {
+	if t.Version == 0 {
+		return fmt.Sprintf("%s/%s@default", t.Name, t.Language)
+	}
+	return fmt.Sprintf("%s/%s@v%d", t.Name, t.Language, t.Version)
+}
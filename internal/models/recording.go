@@ -0,0 +1,11 @@
+package models
+
+import "time"
+
+// PauseMarker marks a pause of a recording: the audio was paused at PausedAt and resumed at ResumedAt,
+// Position is where the pause falls in the recorded audio, in seconds
+type PauseMarker struct {
+	Position  float64   `json:"position"`
+	PausedAt  time.Time `json:"paused_at"`
+	ResumedAt time.Time `json:"resumed_at"`
+}
@@ -0,0 +1,110 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+type (
+	// TranscriptSegment is a piece of the transcript with its position in the recording, in seconds
+	TranscriptSegment struct {
+		Start   float64 `json:"start"`
+		End     float64 `json:"end"`
+		Text    string  `json:"text"`
+		Speaker string  `json:"speaker,omitempty"`
+	}
+
+	// TranscriptSegments is stored as a JSON column
+	TranscriptSegments []TranscriptSegment
+
+	// Transcript keeps the full text of a recording together with its timed segments
+	Transcript struct {
+		Text     string             `json:"text"`
+		Segments TranscriptSegments `json:"segments"`
+	}
+
+	// SpeakerTurn is a time range of the recording in which one speaker talks
+	SpeakerTurn struct {
+		Start   float64 `json:"start"`
+		End     float64 `json:"end"`
+		Speaker string  `json:"speaker"`
+	}
+)
+
+// Speakers of recordings captured by the app: the microphone is the user, the system audio is the other side
+const (
+	SpeakerMe     = "Me"
+	SpeakerRemote = "Remote"
+)
+
+// Offset shifts all segments by the given number of seconds
+func (s TranscriptSegments) Offset(seconds float64) TranscriptSegments {
+	out := make(TranscriptSegments, len(s))
+	for i, seg := range s {
+		out[i] = seg
+		out[i].Start += seconds
+		out[i].End += seconds
+	}
+
+	return out
+}
+
+// HasSpeakers reports whether at least one segment is labelled with a speaker
+func (s TranscriptSegments) HasSpeakers() bool {
+	for _, seg := range s {
+		if seg.Speaker != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// RenameSpeaker replaces the speaker name in all segments and reports whether any segment was changed
+func (s TranscriptSegments) RenameSpeaker(from, to string) bool {
+	var changed bool
+	for i := range s {
+		if s[i].Speaker == from {
+			s[i].Speaker = to
+			changed = true
+		}
+	}
+	return changed
+}
+
+// Value implements driver.Valuer
+func (s TranscriptSegments) Value() (driver.Value, error) {
+	if s == nil {
+		return nil, nil
+	}
+
+	body, err := json.Marshal(s)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal transcript segments: %w", err)
+	}
+
+	return string(body), nil
+}
+
+// Scan implements sql.Scanner
+func (s *TranscriptSegments) Scan(src any) error {
+	var body []byte
+	switch v := src.(type) {
+	case nil:
+		*s = nil
+		return nil
+	case []byte:
+		body = v
+	case string:
+		body = []byte(v)
+	default:
+		return fmt.Errorf("unsupported transcript segments type: %T", src)
+	}
+
+	if len(body) == 0 || string(body) == "null" {
+		*s = nil
+		return nil
+	}
+
+	return json.Unmarshal(body, s)
+}
@@ -0,0 +1,84 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+type (
+	// Usage is what a single API request used and its estimated cost in USD.
+	// Requests made by a processing job are linked to the interview or call the job saved.
+	Usage struct {
+		ID               uint64    `json:"id" gorm:"primaryKey" db:"id"`
+		Operation        string    `json:"operation" gorm:"not null" db:"operation"`
+		Model            string    `json:"model" gorm:"index;not null" db:"model"`
+		AudioSeconds     float64   `json:"audio_seconds,omitempty" db:"audio_seconds"`
+		PromptTokens     int64     `json:"prompt_tokens,omitempty" db:"prompt_tokens"`
+		CompletionTokens int64     `json:"completion_tokens,omitempty" db:"completion_tokens"`
+		Cost             float64   `json:"cost" db:"cost"`
+		JobID            uint64    `json:"job_id,omitempty" gorm:"index" db:"job_id"`
+		InterviewID      uint64    `json:"interview_id,omitempty" gorm:"index" db:"interview_id"`
+		CallID           uint64    `json:"call_id,omitempty" gorm:"index" db:"call_id"`
+		CreatedAt        time.Time `json:"created_at" gorm:"index;autoCreateTime" db:"created_at"`
+	}
+
+	// UsageTotal sums the usage of the requests made in a day or a month, or with a model
+	UsageTotal struct {
+		Key              string  `json:"key"`
+		Requests         int64   `json:"requests"`
+		AudioSeconds     float64 `json:"audio_seconds"`
+		PromptTokens     int64   `json:"prompt_tokens"`
+		CompletionTokens int64   `json:"completion_tokens"`
+		Cost             float64 `json:"cost"`
+	}
+
+	// CostEstimate is the expected cost in USD of processing a recording, by step
+	CostEstimate struct {
+		AudioSeconds float64 `json:"audio_seconds"`
+		Transcribe   float64 `json:"transcribe"`
+		Diarize      float64 `json:"diarize"`
+		Analyze      float64 `json:"analyze"`
+		Total        float64 `json:"total"`
+	}
+
+	// UsageGroup is how usage totals are grouped
+	UsageGroup string
+
+	// GetUsageFilters represents filters for querying usage, zero values match everything
+	GetUsageFilters struct {
+		DateFrom    *time.Time `json:"dateFrom,omitempty"`
+		DateTo      *time.Time `json:"dateTo,omitempty"`
+		JobID       uint64     `json:"jobId,omitempty"`
+		InterviewID uint64     `json:"interviewId,omitempty"`
+		CallID      uint64     `json:"callId,omitempty"`
+	}
+)
+
+const (
+	UsageByDay   UsageGroup = "day"
+	UsageByMonth UsageGroup = "month"
+	UsageByModel UsageGroup = "model"
+)
+
+// Key returns the key of the total the usage is added to
+func (g UsageGroup) Key(u Usage) (string, error) {
+	switch g {
+	case UsageByDay:
+		return u.CreatedAt.Local().Format("2006-01-02"), nil
+	case UsageByMonth:
+		return u.CreatedAt.Local().Format("2006-01"), nil
+	case UsageByModel:
+		return u.Model, nil
+	default:
+		return "", fmt.Errorf("unknown usage group: %q", g)
+	}
+}
+
+// Add adds the usage of a request to the total
+func (t *UsageTotal) Add(u Usage) {
+	t.Requests++
+	t.AudioSeconds += u.AudioSeconds
+	t.PromptTokens += u.PromptTokens
+	t.CompletionTokens += u.CompletionTokens
+	t.Cost += u.Cost
+}
@@ -1,6 +1,7 @@
 package parser
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"os"
@@ -13,8 +14,9 @@ import (
 )
 
 // getDuration returns media duration in seconds using ffprobe.
-func getDuration(mediaPath string) (float64, error) {
-	cmd := exec.Command(
+func getDuration(ctx context.Context, mediaPath string) (float64, error) {
+	cmd := exec.CommandContext(
+		ctx,
 		ffprobePath(),
 		"-v", "error",
 		"-show_entries", "format=duration",
@@ -36,43 +38,60 @@ func getDuration(mediaPath string) (float64, error) {
 	return dur, nil
 }
 
-// SplitIntoChunks splits audio file into N-second chunks using ffmpeg.
-// Produces .m4a chunks inside output/chunks.
-func (p *Parser) SplitIntoChunks(cfg *config.Config, inputPath string) ([]string, error) {
+// SplitIntoChunks splits audio file into chunks of about N seconds, cutting at pauses when possible.
+// PCM WAV files are split natively into mono 16 kHz WAV chunks, other formats with ffmpeg into .m4a chunks.
+// Chunks are placed inside dir, each with its start in the original file.
+// Cancelling ctx kills the running ffmpeg process.
+func (p *Parser) SplitIntoChunks(ctx context.Context, cfg *config.Config, inputPath, dir string) ([]Chunk, error) {
 	file, err := os.Open(inputPath)
 	if err != nil {
 		return nil, err
 	}
 	defer file.Close()
 
-	duration, err := getDuration(inputPath)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	if isPCMWAV(inputPath) {
+		return p.splitWAV(ctx, cfg, inputPath, dir)
+	}
+
+	duration, err := getDuration(ctx, inputPath)
 	if err != nil {
 		return nil, err
 	}
 	log.Printf("[i] Media duration: %.2fs\n", duration)
 
-	if err := os.MkdirAll(cfg.ChunksDir, 0o755); err != nil {
-		return nil, err
+	var silences []silence
+	if cfg.ChunkSilenceWindow > 0 || cfg.DropSilenceSeconds > 0 {
+		silences, err = detectSilences(ctx, inputPath, cfg.SilenceThresholdDB, cfg.SilenceMinDuration)
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if err != nil {
+			// chunks are cut at fixed length without the pauses
+			log.Printf("[i] Silence detection failed: %v\n", err)
+		}
+		log.Printf("[i] Silences detected: %d\n", len(silences))
 	}
 
-	var (
-		chunkPaths []string
-		start      float64
-		idx        int
-	)
+	var chunks []Chunk
 
 	ext, codec := outputFormatFor(inputPath)
 	base := strings.TrimSuffix(filepath.Base(inputPath), filepath.Ext(inputPath))
 
-	for start < duration {
-		outPath := filepath.Join(cfg.ChunksDir, fmt.Sprintf("%s_chunk_%03d%s", base, idx, ext))
+	for idx, part := range planChunks(cfg.TranscribeConfig, duration, silences) {
+		start := part.Start
+		outPath := filepath.Join(dir, fmt.Sprintf("%s_chunk_%03d%s", base, idx, ext))
 
-		cmd := exec.Command(
+		cmd := exec.CommandContext(
+			ctx,
 			ffmpegPath(),
 			"-loglevel", "error",
 			"-y",
 			"-ss", fmt.Sprintf("%.2f", start),
-			"-t", strconv.Itoa(cfg.ChunkSeconds),
+			"-t", fmt.Sprintf("%.2f", part.End-part.Start),
 			"-i", inputPath,
 			"-vn",
 			"-acodec", codec,
@@ -85,27 +104,42 @@ func (p *Parser) SplitIntoChunks(cfg *config.Config, inputPath string) ([]string
 		cmd.Stderr = os.Stderr
 
 		if err := cmd.Run(); err != nil {
+			removeChunkFiles(append(chunks, Chunk{Path: outPath}))
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
 			return nil, err
 		}
 
-		chunkPaths = append(chunkPaths, outPath)
-		idx++
-		start += float64(cfg.ChunkSeconds)
+		chunks = append(chunks, Chunk{
+			Path:     outPath,
+			Start:    start,
+			Duration: part.End - part.Start,
+			Overlap:  part.Overlap,
+		})
 	}
 
-	log.Printf("[i] Total chunks: %d\n", len(chunkPaths))
-	return chunkPaths, nil
+	log.Printf("[i] Total chunks: %d\n", len(chunks))
+	return chunks, nil
+}
+
+// removeChunkFiles deletes the chunks made before the splitting failed
+func removeChunkFiles(chunks []Chunk) {
+	for _, chunk := range chunks {
+		os.Remove(chunk.Path)
+	}
 }
 
-func (p *Parser) LoadChunks(cfg *config.Config) ([]string, error) {
-	dir, err := os.ReadDir(cfg.ChunksDir)
+// LoadChunks returns the chunk files in the chunk directory of a job
+func (p *Parser) LoadChunks(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
 	if err != nil {
 		return nil, fmt.Errorf("read dir error: %w", err)
 	}
 
-	chunkPaths := make([]string, 0, len(dir))
-	for _, de := range dir {
-		chunkPaths = append(chunkPaths, filepath.Join(cfg.ChunksDir, de.Name()))
+	chunkPaths := make([]string, 0, len(entries))
+	for _, de := range entries {
+		chunkPaths = append(chunkPaths, filepath.Join(dir, de.Name()))
 	}
 
 	return chunkPaths, nil
@@ -0,0 +1,244 @@
+package parser
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mrbelka12000/interview_parser/internal/config"
+	"github.com/mrbelka12000/interview_parser/internal/wav"
+)
+
+const (
+	// transcribeSampleRate is the sample rate of the chunks made from WAV files, enough for speech
+	transcribeSampleRate = 16000
+	// silenceFrameSeconds is the resolution of the silence detection of WAV files
+	silenceFrameSeconds = 0.02
+)
+
+// isPCMWAV reports whether the file is a PCM WAV file that is split without ffmpeg
+func isPCMWAV(mediaPath string) bool {
+	if !strings.EqualFold(filepath.Ext(mediaPath), ".wav") {
+		return false
+	}
+
+	r, err := wav.OpenReader(mediaPath)
+	if err != nil {
+		return false
+	}
+	defer r.Close()
+
+	return true
+}
+
+// Duration returns the duration of the media file in seconds, read from the header of PCM WAV files
+// and with ffprobe for other formats
+func (p *Parser) Duration(ctx context.Context, mediaPath string) (float64, error) {
+	if !isPCMWAV(mediaPath) {
+		return getDuration(ctx, mediaPath)
+	}
+
+	r, err := wav.OpenReader(mediaPath)
+	if err != nil {
+		return 0, err
+	}
+	defer r.Close()
+
+	return r.Duration(), nil
+}
+
+// splitWAV converts the PCM WAV file to mono 16 kHz and splits it into WAV chunks, without ffmpeg
+func (p *Parser) splitWAV(ctx context.Context, cfg *config.Config, inputPath, dir string) ([]Chunk, error) {
+	base := strings.TrimSuffix(filepath.Base(inputPath), filepath.Ext(inputPath))
+	monoPath := filepath.Join(dir, base+"_mono.wav")
+	defer os.Remove(monoPath)
+
+	silences, err := convertToMono(ctx, cfg, inputPath, monoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	mono, err := wav.OpenReader(monoPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open converted audio: %w", err)
+	}
+	defer mono.Close()
+
+	duration := mono.Duration()
+	log.Printf("[i] Media duration: %.2fs\n", duration)
+
+	var (
+		chunks []Chunk
+		writer = wav.NewWriter(transcribeSampleRate, 1, 16)
+	)
+	if cfg.ChunkSilenceWindow == 0 && cfg.DropSilenceSeconds == 0 {
+		silences = nil
+	}
+
+	for idx, part := range planChunks(cfg.TranscribeConfig, duration, silences) {
+		if err = ctx.Err(); err != nil {
+			removeChunkFiles(chunks)
+			return nil, err
+		}
+		outPath := filepath.Join(dir, fmt.Sprintf("%s_chunk_%03d.wav", base, idx))
+
+		// 2 bytes per sample, whole samples only
+		from := int64(part.Start*transcribeSampleRate) * 2
+		to := min(int64(part.End*transcribeSampleRate)*2, mono.DataSize)
+		if to <= from {
+			continue
+		}
+
+		data := make([]byte, to-from)
+		if _, err = mono.ReadAt(data, from); err != nil && !errors.Is(err, io.EOF) {
+			removeChunkFiles(chunks)
+			return nil, fmt.Errorf("failed to read chunk %d: %w", idx, err)
+		}
+
+		log.Printf("[i] Creating chunk %d: start=%.2fs → %s\n", idx, part.Start, filepath.Base(outPath))
+		if err = writer.SaveAsWAV(outPath, data); err != nil {
+			removeChunkFiles(append(chunks, Chunk{Path: outPath}))
+			return nil, fmt.Errorf("failed to save chunk %d: %w", idx, err)
+		}
+
+		chunks = append(chunks, Chunk{
+			Path:     outPath,
+			Start:    part.Start,
+			Duration: part.End - part.Start,
+			Overlap:  part.Overlap,
+		})
+	}
+
+	log.Printf("[i] Total chunks: %d\n", len(chunks))
+	return chunks, nil
+}
+
+// convertToMono writes the audio as mono 16 kHz into outPath and finds its silences on the way
+func convertToMono(ctx context.Context, cfg *config.Config, inputPath, outPath string) ([]silence, error) {
+	r, err := wav.OpenReader(inputPath)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	conv, err := wav.NewMonoConverter(r, transcribeSampleRate)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := wav.NewStreamWriter(outPath, transcribeSampleRate, 1, 16)
+	if err != nil {
+		return nil, err
+	}
+	defer out.Close()
+
+	var (
+		detector = newSilenceDetector(cfg.SilenceThresholdDB, cfg.SilenceMinDuration)
+		samples  = make([]int16, transcribeSampleRate)
+		buf      = make([]byte, len(samples)*2)
+	)
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		n, err := conv.Read(samples)
+		if n > 0 {
+			detector.add(samples[:n])
+			for i, s := range samples[:n] {
+				buf[i*2] = byte(s)
+				buf[i*2+1] = byte(s >> 8)
+			}
+			if _, err := out.Write(buf[:n*2]); err != nil {
+				return nil, err
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err = out.Close(); err != nil {
+		return nil, err
+	}
+
+	return detector.finish(), nil
+}
+
+// silenceDetector finds the stretches of mono 16 kHz audio quieter than the threshold,
+// like the ffmpeg silencedetect filter does for other formats
+type silenceDetector struct {
+	threshold   float64
+	minDuration float64
+	frameSize   int
+
+	energy  float64
+	samples int
+	frames  int
+
+	start    float64
+	inSilent bool
+	silences []silence
+}
+
+func newSilenceDetector(thresholdDB int, minDuration float64) *silenceDetector {
+	return &silenceDetector{
+		threshold:   math.Pow(10, float64(thresholdDB)/20) * 32768,
+		minDuration: minDuration,
+		frameSize:   int(silenceFrameSeconds * transcribeSampleRate),
+	}
+}
+
+func (d *silenceDetector) add(samples []int16) {
+	for _, s := range samples {
+		d.energy += float64(s) * float64(s)
+		d.samples++
+		if d.samples == d.frameSize {
+			d.frame()
+		}
+	}
+}
+
+// frame closes a frame and extends or ends the current silence
+func (d *silenceDetector) frame() {
+	var (
+		at     = float64(d.frames) * silenceFrameSeconds
+		silent = math.Sqrt(d.energy/float64(d.samples)) < d.threshold
+	)
+	d.energy, d.samples = 0, 0
+	d.frames++
+
+	switch {
+	case silent && !d.inSilent:
+		d.start, d.inSilent = at, true
+	case !silent && d.inSilent:
+		d.inSilent = false
+		if at-d.start >= d.minDuration {
+			d.silences = append(d.silences, silence{Start: d.start, End: at})
+		}
+	}
+}
+
+// finish returns the silences, a silence lasting to the end of the audio is closed there
+func (d *silenceDetector) finish() []silence {
+	if d.samples > 0 {
+		d.frame()
+	}
+
+	end := float64(d.frames) * silenceFrameSeconds
+	if d.inSilent && end-d.start >= d.minDuration {
+		d.silences = append(d.silences, silence{Start: d.start, End: math.Inf(1)})
+	}
+	d.inSilent = false
+
+	return d.silences
+}
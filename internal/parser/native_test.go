@@ -0,0 +1,123 @@
+package parser
+
+import (
+	"context"
+	"encoding/binary"
+	"math"
+	"path/filepath"
+	"testing"
+
+	"github.com/mrbelka12000/interview_parser/internal/config"
+	"github.com/mrbelka12000/interview_parser/internal/wav"
+)
+
+// toneOrSilence returns mono samples at sampleRate, a 440 Hz tone for the true parts and silence for the false ones
+func toneOrSilence(sampleRate int, parts []bool, seconds []float64) []int16 {
+	var samples []int16
+	for i, tone := range parts {
+		n := int(seconds[i] * float64(sampleRate))
+		for j := 0; j < n; j++ {
+			var v float64
+			if tone {
+				v = 10000 * math.Sin(2*math.Pi*440*float64(len(samples))/float64(sampleRate))
+			}
+			samples = append(samples, int16(v))
+		}
+	}
+	return samples
+}
+
+func TestSilenceDetector(t *testing.T) {
+	tests := []struct {
+		name    string
+		parts   []bool
+		seconds []float64
+		want    []silence
+	}{
+		{
+			name:    "silence between speech",
+			parts:   []bool{true, false, true},
+			seconds: []float64{1, 1, 1},
+			want:    []silence{{Start: 1, End: 2}},
+		},
+		{
+			name:    "short pause is not a silence",
+			parts:   []bool{true, false, true},
+			seconds: []float64{1, 0.3, 1},
+		},
+		{
+			name:    "silence lasting to the end",
+			parts:   []bool{false, true, false},
+			seconds: []float64{0.6, 1, 0.8},
+			want:    []silence{{Start: 0, End: 0.6}, {Start: 1.6, End: math.Inf(1)}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := newSilenceDetector(-35, 0.5)
+			samples := toneOrSilence(transcribeSampleRate, tt.parts, tt.seconds)
+			// fed in pieces not aligned with the frames
+			for len(samples) > 0 {
+				n := min(len(samples), 1001)
+				d.add(samples[:n])
+				samples = samples[n:]
+			}
+
+			assertSilences(t, d.finish(), tt.want)
+		})
+	}
+}
+
+func TestConvertToMono(t *testing.T) {
+	var (
+		dir    = t.TempDir()
+		input  = filepath.Join(dir, "input.wav")
+		output = filepath.Join(dir, "output.wav")
+	)
+
+	// 48 kHz stereo: speech, a silent second, speech
+	mono := toneOrSilence(48000, []bool{true, false, true}, []float64{1, 1, 1})
+	data := make([]byte, len(mono)*4)
+	for i, s := range mono {
+		binary.LittleEndian.PutUint16(data[i*4:], uint16(s))
+		binary.LittleEndian.PutUint16(data[i*4+2:], uint16(s))
+	}
+	if err := wav.NewWriter(48000, 2, 16).SaveAsWAV(input, data); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := &config.Config{TranscribeConfig: config.TranscribeConfig{SilenceThresholdDB: -35, SilenceMinDuration: 0.5}}
+	silences, err := convertToMono(context.Background(), cfg, input, output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertSilences(t, silences, []silence{{Start: 1, End: 2}})
+
+	r, err := wav.OpenReader(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	if r.SampleRate != transcribeSampleRate || r.Channels != 1 || r.BitsPerSample != 16 {
+		t.Errorf("format = %d Hz, %d channels, %d bits, want mono 16-bit %d Hz", r.SampleRate, r.Channels, r.BitsPerSample, transcribeSampleRate)
+	}
+	if d := r.Duration(); !closeTo(d, 3) {
+		t.Errorf("Duration() = %v, want 3", d)
+	}
+}
+
+func assertSilences(t *testing.T, got, want []silence) {
+	t.Helper()
+
+	if len(got) != len(want) {
+		t.Fatalf("silences = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		endOK := closeTo(got[i].End, want[i].End) || (math.IsInf(got[i].End, 1) && math.IsInf(want[i].End, 1))
+		if !closeTo(got[i].Start, want[i].Start) || !endOK {
+			t.Errorf("silence %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
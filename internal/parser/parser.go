@@ -8,6 +8,15 @@ type (
 	Parser struct {
 		Cfg *config.Config
 	}
+
+	// Chunk is a part of the media file with its position in the original recording, in seconds.
+	// Overlap is the length of its beginning repeated from the end of the previous chunk.
+	Chunk struct {
+		Path     string
+		Start    float64
+		Duration float64
+		Overlap  float64
+	}
 )
 
 func NewParser(cfg *config.Config) *Parser {
@@ -0,0 +1,26 @@
+package parser
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/mrbelka12000/interview_parser/internal/models"
+)
+
+// pausesFileSuffix is appended to the media file name for its pause markers
+const pausesFileSuffix = ".pauses.json"
+
+// SavePauseMarkers stores the pauses of a recording next to the media file
+func (p *Parser) SavePauseMarkers(mediaPath string, pauses []models.PauseMarker) error {
+	body, err := json.Marshal(pauses)
+	if err != nil {
+		return fmt.Errorf("failed to marshal pause markers: %w", err)
+	}
+
+	if err = os.WriteFile(mediaPath+pausesFileSuffix, body, 0o644); err != nil {
+		return fmt.Errorf("failed to write pause markers: %w", err)
+	}
+
+	return nil
+}
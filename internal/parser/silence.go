@@ -0,0 +1,183 @@
+package parser
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"math"
+	"os/exec"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/mrbelka12000/interview_parser/internal/config"
+)
+
+// silenceMargin is the part of a dropped silence kept at each side, so words are not clipped
+const silenceMargin = 0.3
+
+var (
+	silenceStartRe = regexp.MustCompile(`silence_start: (-?[\d.]+)`)
+	silenceEndRe   = regexp.MustCompile(`silence_end: (-?[\d.]+)`)
+)
+
+type (
+	// silence is a quiet stretch of the media, in seconds
+	silence struct {
+		Start float64
+		End   float64
+	}
+
+	// span is a part of the media to transcribe, in seconds
+	span struct {
+		Start   float64
+		End     float64
+		Overlap float64
+	}
+)
+
+// detectSilences finds the quiet stretches of the media with the ffmpeg silencedetect filter
+func detectSilences(ctx context.Context, mediaPath string, thresholdDB int, minDuration float64) ([]silence, error) {
+	cmd := exec.CommandContext(
+		ctx,
+		ffmpegPath(),
+		"-hide_banner",
+		"-nostats",
+		"-i", mediaPath,
+		"-vn",
+		"-af", fmt.Sprintf("silencedetect=noise=%ddB:d=%.2f", thresholdDB, minDuration),
+		"-f", "null",
+		"-",
+	)
+
+	// silencedetect reports to the log, which goes to stderr
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("failed to detect silence: %w", err)
+	}
+
+	return parseSilences(stderr.Bytes()), nil
+}
+
+// parseSilences reads the silencedetect log. A silence lasting to the end of the media has no end line.
+func parseSilences(out []byte) []silence {
+	var (
+		silences []silence
+		start    = math.NaN()
+		scanner  = bufio.NewScanner(bytes.NewReader(out))
+	)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if m := silenceStartRe.FindStringSubmatch(line); m != nil {
+			start, _ = strconv.ParseFloat(m[1], 64)
+			continue
+		}
+		if m := silenceEndRe.FindStringSubmatch(line); m != nil && !math.IsNaN(start) {
+			end, err := strconv.ParseFloat(m[1], 64)
+			if err == nil && end > start {
+				silences = append(silences, silence{Start: max(start, 0), End: end})
+			}
+			start = math.NaN()
+		}
+	}
+	if !math.IsNaN(start) {
+		silences = append(silences, silence{Start: max(start, 0), End: math.Inf(1)})
+	}
+
+	return silences
+}
+
+// planChunks splits the media into chunks of about ChunkSeconds, cutting at the pause nearest to the
+// target length within ChunkSilenceWindow seconds. Silences longer than DropSilenceSeconds are left out.
+// Adjacent chunks overlap by ChunkOverlapSeconds so the words at the cut are heard whole by one of them.
+func planChunks(cfg config.TranscribeConfig, duration float64, silences []silence) []span {
+	sort.Slice(silences, func(i, j int) bool { return silences[i].Start < silences[j].Start })
+
+	var (
+		chunkSeconds = float64(cfg.ChunkSeconds)
+		window       = float64(cfg.ChunkSilenceWindow)
+		chunks       []span
+	)
+	if chunkSeconds <= 0 {
+		return speechSpans(duration, silences, cfg.DropSilenceSeconds)
+	}
+
+	for _, part := range speechSpans(duration, silences, cfg.DropSilenceSeconds) {
+		start := part.Start
+		for part.End-start > chunkSeconds+window {
+			target := start + chunkSeconds
+			cut := target
+
+			best := math.Inf(1)
+			for _, s := range silences {
+				// the cut is the point of the pause nearest to the target, away from its edges
+				end := min(s.End, part.End)
+				margin := min(silenceMargin, (end-s.Start)/2)
+				point := min(max(target, s.Start+margin), end-margin)
+				if point <= start || point >= part.End {
+					continue
+				}
+				if d := math.Abs(point - target); d <= window && d < best {
+					best, cut = d, point
+				}
+			}
+
+			chunks = append(chunks, span{Start: start, End: cut})
+			start = cut
+		}
+		chunks = append(chunks, span{Start: start, End: part.End})
+	}
+
+	for i := 1; i < len(chunks); i++ {
+		// chunks separated by a dropped silence have nothing to share
+		if prev := chunks[i-1]; prev.End == chunks[i].Start {
+			start := max(chunks[i].Start-cfg.ChunkOverlapSeconds, prev.Start)
+			chunks[i].Overlap = chunks[i].Start - start
+			chunks[i].Start = start
+		}
+	}
+
+	return chunks
+}
+
+// speechSpans returns the media without the silences longer than dropSeconds, all of it when dropSeconds is 0
+func speechSpans(duration float64, silences []silence, dropSeconds float64) []span {
+	if dropSeconds <= 0 {
+		return []span{{Start: 0, End: duration}}
+	}
+
+	var (
+		spans      []span
+		speechFrom float64
+	)
+	for _, s := range silences {
+		end := min(s.End, duration)
+		if end-s.Start < dropSeconds {
+			continue
+		}
+
+		if s.Start > speechFrom {
+			spans = append(spans, marginSpan(spans, speechFrom, s.Start))
+		}
+		speechFrom = max(speechFrom, end)
+	}
+	if duration > speechFrom {
+		spans = append(spans, marginSpan(spans, speechFrom, duration))
+	}
+	if n := len(spans); n > 0 {
+		spans[n-1].End = min(spans[n-1].End, duration)
+	}
+
+	return spans
+}
+
+// marginSpan returns the speech between from and to widened by silenceMargin, not overlapping the previous span
+func marginSpan(spans []span, from, to float64) span {
+	start := max(from-silenceMargin, 0)
+	if n := len(spans); n > 0 {
+		start = max(start, spans[n-1].End)
+	}
+	return span{Start: start, End: to + silenceMargin}
+}
@@ -0,0 +1,144 @@
+package parser
+
+import (
+	"math"
+	"testing"
+
+	"github.com/mrbelka12000/interview_parser/internal/config"
+)
+
+func TestPlanChunks(t *testing.T) {
+	base := config.TranscribeConfig{
+		ChunkSeconds:        100,
+		ChunkSilenceWindow:  20,
+		ChunkOverlapSeconds: 2,
+	}
+
+	tests := []struct {
+		name     string
+		cfg      config.TranscribeConfig
+		duration float64
+		silences []silence
+		want     []span
+	}{
+		{
+			name:     "shorter than a chunk",
+			cfg:      base,
+			duration: 90,
+			want:     []span{{Start: 0, End: 90}},
+		},
+		{
+			name:     "one window past the chunk length is not cut",
+			cfg:      base,
+			duration: 120,
+			want:     []span{{Start: 0, End: 120}},
+		},
+		{
+			name:     "fixed length cuts without silences",
+			cfg:      base,
+			duration: 250,
+			want: []span{
+				{Start: 0, End: 100},
+				{Start: 98, End: 200, Overlap: 2},
+				{Start: 198, End: 250, Overlap: 2},
+			},
+		},
+		{
+			name:     "cut inside the nearest pause",
+			cfg:      base,
+			duration: 250,
+			silences: []silence{{Start: 110, End: 111}},
+			want: []span{
+				{Start: 0, End: 110.3},
+				{Start: 108.3, End: 210.3, Overlap: 2},
+				{Start: 208.3, End: 250, Overlap: 2},
+			},
+		},
+		{
+			name:     "pause outside the window is ignored",
+			cfg:      base,
+			duration: 250,
+			silences: []silence{{Start: 130, End: 131}},
+			want: []span{
+				{Start: 0, End: 100},
+				{Start: 98, End: 200, Overlap: 2},
+				{Start: 198, End: 250, Overlap: 2},
+			},
+		},
+		{
+			name: "overlap limited by the previous chunk",
+			cfg: config.TranscribeConfig{
+				ChunkSeconds:        10,
+				ChunkOverlapSeconds: 15,
+			},
+			duration: 25,
+			want: []span{
+				{Start: 0, End: 10},
+				{Start: 0, End: 20, Overlap: 10},
+				{Start: 5, End: 25, Overlap: 15},
+			},
+		},
+		{
+			name: "no overlap across a dropped silence",
+			cfg: config.TranscribeConfig{
+				ChunkSeconds:        100,
+				ChunkOverlapSeconds: 2,
+				DropSilenceSeconds:  5,
+			},
+			duration: 100,
+			silences: []silence{{Start: 40, End: 60}},
+			want: []span{
+				{Start: 0, End: 40.3},
+				{Start: 59.7, End: 100},
+			},
+		},
+		{
+			name: "short silences are kept",
+			cfg: config.TranscribeConfig{
+				ChunkSeconds:       100,
+				DropSilenceSeconds: 5,
+			},
+			duration: 100,
+			silences: []silence{{Start: 40, End: 44}},
+			want:     []span{{Start: 0, End: 100}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := planChunks(tt.cfg, tt.duration, tt.silences)
+			if len(got) != len(tt.want) {
+				t.Fatalf("planChunks() = %+v, want %+v", got, tt.want)
+			}
+			for i := range got {
+				if !closeTo(got[i].Start, tt.want[i].Start) || !closeTo(got[i].End, tt.want[i].End) || !closeTo(got[i].Overlap, tt.want[i].Overlap) {
+					t.Errorf("chunk %d = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestParseSilences(t *testing.T) {
+	out := []byte(`[silencedetect @ 0x1] silence_start: 1.5
+[silencedetect @ 0x1] silence_end: 3.25 | silence_duration: 1.75
+[silencedetect @ 0x1] silence_start: -0.01
+[silencedetect @ 0x1] silence_end: 0.5 | silence_duration: 0.51
+[silencedetect @ 0x1] silence_start: 9
+`)
+
+	got := parseSilences(out)
+	want := []silence{{Start: 1.5, End: 3.25}, {Start: 0, End: 0.5}, {Start: 9, End: math.Inf(1)}}
+	if len(got) != len(want) {
+		t.Fatalf("parseSilences() = %+v, want %+v", got, want)
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			t.Errorf("silence %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func closeTo(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
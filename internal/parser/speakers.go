@@ -0,0 +1,155 @@
+package parser
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mrbelka12000/interview_parser/internal/models"
+)
+
+// speakersFileSuffix is appended to the media file name for its speaker timeline
+const speakersFileSuffix = ".speakers.json"
+
+// SaveSpeakerTurns stores the speaker timeline of a recording next to the media file
+func (p *Parser) SaveSpeakerTurns(mediaPath string, turns []models.SpeakerTurn) error {
+	body, err := json.Marshal(turns)
+	if err != nil {
+		return fmt.Errorf("failed to marshal speaker turns: %w", err)
+	}
+
+	if err = os.WriteFile(mediaPath+speakersFileSuffix, body, 0o644); err != nil {
+		return fmt.Errorf("failed to write speaker turns: %w", err)
+	}
+
+	return nil
+}
+
+// LoadSpeakerTurns reads the speaker timeline stored next to the media file.
+// Files without a timeline (e.g. uploads) return no turns and no error.
+func (p *Parser) LoadSpeakerTurns(mediaPath string) ([]models.SpeakerTurn, error) {
+	body, err := os.ReadFile(mediaPath + speakersFileSuffix)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read speaker turns: %w", err)
+	}
+
+	var turns []models.SpeakerTurn
+	if err = json.Unmarshal(body, &turns); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal speaker turns: %w", err)
+	}
+
+	return turns, nil
+}
+
+// ApplySpeakerTurns labels the segments with the speakers of the timeline.
+// A segment spanning several turns is split, its words are shared in proportion to the turn durations.
+func (p *Parser) ApplySpeakerTurns(segments models.TranscriptSegments, turns []models.SpeakerTurn) models.TranscriptSegments {
+	if len(turns) == 0 {
+		return segments
+	}
+
+	out := make(models.TranscriptSegments, 0, len(segments))
+	for _, seg := range segments {
+		var overlaps []models.SpeakerTurn
+		for _, turn := range turns {
+			start, end := max(seg.Start, turn.Start), min(seg.End, turn.End)
+			if end <= start {
+				continue
+			}
+
+			// neighbouring turns of the same speaker are merged
+			if n := len(overlaps); n > 0 && overlaps[n-1].Speaker == turn.Speaker {
+				overlaps[n-1].End = end
+				continue
+			}
+			overlaps = append(overlaps, models.SpeakerTurn{Start: start, End: end, Speaker: turn.Speaker})
+		}
+
+		switch len(overlaps) {
+		case 0:
+			out = append(out, seg)
+		case 1:
+			seg.Speaker = overlaps[0].Speaker
+			out = append(out, seg)
+		default:
+			out = append(out, splitByTurns(seg, overlaps)...)
+		}
+	}
+
+	return out
+}
+
+// SplitByText splits the segment into the given speaker parts, the time is shared in proportion to the text length
+func (p *Parser) SplitByText(seg models.TranscriptSegment, parts []models.TranscriptSegment) models.TranscriptSegments {
+	var total int
+	for _, part := range parts {
+		total += utf8.RuneCountInString(part.Text)
+	}
+	if total == 0 {
+		return models.TranscriptSegments{seg}
+	}
+
+	var (
+		out   = make(models.TranscriptSegments, 0, len(parts))
+		start = seg.Start
+		done  int
+	)
+	for _, part := range parts {
+		done += utf8.RuneCountInString(part.Text)
+		end := seg.Start + (seg.End-seg.Start)*float64(done)/float64(total)
+		out = append(out, models.TranscriptSegment{
+			Start:   start,
+			End:     end,
+			Text:    part.Text,
+			Speaker: part.Speaker,
+		})
+		start = end
+	}
+
+	return out
+}
+
+// splitByTurns shares the words of the segment between the turns by their duration
+func splitByTurns(seg models.TranscriptSegment, turns []models.SpeakerTurn) models.TranscriptSegments {
+	var (
+		words    = strings.Fields(seg.Text)
+		out      = make(models.TranscriptSegments, 0, len(turns))
+		duration = seg.End - seg.Start
+		from     int
+	)
+
+	for i, turn := range turns {
+		to := len(words)
+		if i < len(turns)-1 && duration > 0 {
+			to = from + int(float64(len(words))*(turn.End-turn.Start)/duration+0.5)
+			to = min(to, len(words))
+		}
+		if to <= from {
+			continue
+		}
+
+		out = append(out, models.TranscriptSegment{
+			Start:   turn.Start,
+			End:     turn.End,
+			Text:    strings.Join(words[from:to], " "),
+			Speaker: turn.Speaker,
+		})
+		from = to
+	}
+
+	if len(out) == 0 {
+		return models.TranscriptSegments{seg}
+	}
+
+	// the first and the last part keep the segment bounds
+	out[0].Start = seg.Start
+	out[len(out)-1].End = seg.End
+
+	return out
+}
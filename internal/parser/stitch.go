@@ -0,0 +1,158 @@
+package parser
+
+import (
+	"strings"
+
+	"github.com/mrbelka12000/interview_parser/internal/models"
+)
+
+const (
+	// stitchWordsPerSecond and stitchSlackWords bound how many words at the chunk edges are aligned
+	stitchWordsPerSecond = 4
+	stitchSlackWords     = 5
+	// minStitchWords is the shortest run of words accepted as the repeated overlap
+	minStitchWords = 2
+)
+
+// StitchTranscripts joins the transcripts of the chunks into one. The text repeated by overlapping chunks
+// is found by aligning the words at the edges and kept once; the segments lose the same words, so they
+// agree with the text, and are offset to the original recording. Where the segments of two chunks still
+// overlap in time, the timing of the later chunk is kept. Chunks without timed segments get one segment.
+func (p *Parser) StitchTranscripts(chunks []Chunk, transcripts []models.Transcript) *models.Transcript {
+	var (
+		words    []string
+		segments = models.TranscriptSegments{}
+	)
+
+	for i, chunk := range chunks {
+		var (
+			transcript = transcripts[i]
+			next       = strings.Fields(transcript.Text)
+			overlap    = chunk.Overlap
+			// dropped is how many words the previous text and the segments of the chunk lose
+			droppedPrev, droppedNext int
+			aligned                  bool
+		)
+		if i == 0 || len(words) == 0 {
+			overlap = 0
+		}
+
+		if overlap > 0 {
+			window := int(overlap*stitchWordsPerSecond) + stitchSlackWords
+			var cut, from int
+			if cut, from, aligned = alignWords(words, next, window); aligned {
+				droppedPrev, droppedNext = len(words)-cut, from
+				words = words[:cut]
+			}
+		}
+		kept := next[droppedNext:]
+		words = append(words, kept...)
+
+		chunkSegments := dropLeadingWords(transcript.Segments.Offset(chunk.Start), droppedNext)
+		if len(transcript.Segments) == 0 && len(kept) > 0 {
+			start := chunk.Start
+			if aligned {
+				// the kept words follow the repeated overlap
+				start += overlap
+			}
+			chunkSegments = models.TranscriptSegments{{
+				Start: start,
+				End:   chunk.Start + chunk.Duration,
+				Text:  strings.Join(kept, " "),
+			}}
+		}
+		segments = dropTrailingWords(segments, droppedPrev)
+
+		if n := len(segments); n > 0 && len(chunkSegments) > 0 && chunkSegments[0].Start < segments[n-1].End {
+			segments[n-1].End = max(chunkSegments[0].Start, segments[n-1].Start)
+			chunkSegments[0].Start = segments[n-1].End
+			chunkSegments[0].End = max(chunkSegments[0].End, chunkSegments[0].Start)
+		}
+		segments = append(segments, chunkSegments...)
+	}
+
+	return &models.Transcript{
+		Text:     strings.Join(words, " "),
+		Segments: segments,
+	}
+}
+
+// dropLeadingWords removes the first n words from the segments. A segment losing part of its words
+// starts later by their share of its duration, segments losing all of them are removed.
+func dropLeadingWords(segments models.TranscriptSegments, n int) models.TranscriptSegments {
+	for n > 0 && len(segments) > 0 {
+		seg := &segments[0]
+		segWords := strings.Fields(seg.Text)
+		if len(segWords) <= n {
+			n -= len(segWords)
+			segments = segments[1:]
+			continue
+		}
+
+		seg.Start += (seg.End - seg.Start) * float64(n) / float64(len(segWords))
+		seg.Text = strings.Join(segWords[n:], " ")
+		n = 0
+	}
+
+	return segments
+}
+
+// dropTrailingWords removes the last n words from the segments. A segment losing part of its words
+// ends earlier by their share of its duration, segments losing all of them are removed.
+func dropTrailingWords(segments models.TranscriptSegments, n int) models.TranscriptSegments {
+	for n > 0 && len(segments) > 0 {
+		seg := &segments[len(segments)-1]
+		segWords := strings.Fields(seg.Text)
+		if len(segWords) <= n {
+			n -= len(segWords)
+			segments = segments[:len(segments)-1]
+			continue
+		}
+
+		keep := len(segWords) - n
+		seg.End -= (seg.End - seg.Start) * float64(n) / float64(len(segWords))
+		seg.Text = strings.Join(segWords[:keep], " ")
+		n = 0
+	}
+
+	return segments
+}
+
+// alignWords finds the longest run of words shared by the last window words of prev and the first window
+// words of next. It returns where prev is cut (after the run) and where next continues (after the run).
+func alignWords(prev, next []string, window int) (cut, from int, ok bool) {
+	var (
+		tailStart = max(len(prev)-window, 0)
+		tail      = normalizeWords(prev[tailStart:])
+		head      = normalizeWords(next[:min(len(next), window)])
+		bestLen   int
+	)
+
+	for i := range tail {
+		for j := range head {
+			n := 0
+			for i+n < len(tail) && j+n < len(head) && tail[i+n] != "" && tail[i+n] == head[j+n] {
+				n++
+			}
+			if n > bestLen {
+				bestLen = n
+				cut, from = tailStart+i+n, j+n
+			}
+		}
+	}
+
+	if bestLen < minStitchWords {
+		return 0, 0, false
+	}
+
+	return cut, from, true
+}
+
+// normalizeWords lowercases the words and strips their punctuation, so "Hello," matches "hello"
+func normalizeWords(words []string) []string {
+	out := make([]string, len(words))
+	for i, w := range words {
+		out[i] = strings.Join(splitWords(w), "")
+	}
+	return out
+}
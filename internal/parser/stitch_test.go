@@ -0,0 +1,212 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mrbelka12000/interview_parser/internal/models"
+)
+
+func TestAlignWords(t *testing.T) {
+	tests := []struct {
+		name      string
+		prev      string
+		next      string
+		window    int
+		wantCut   int
+		wantFrom  int
+		wantFound bool
+	}{
+		{
+			name:      "repeated words at the edges",
+			prev:      "we use channels to communicate between goroutines",
+			next:      "between goroutines instead of mutexes",
+			window:    10,
+			wantCut:   7,
+			wantFrom:  2,
+			wantFound: true,
+		},
+		{
+			name:      "case and punctuation are ignored",
+			prev:      "they talk Between goroutines,",
+			next:      "between Goroutines. And then",
+			window:    10,
+			wantCut:   4,
+			wantFrom:  2,
+			wantFound: true,
+		},
+		{
+			name:      "the longest run wins",
+			prev:      "so the answer is the answer is yes",
+			next:      "the answer is yes indeed",
+			window:    10,
+			wantCut:   8,
+			wantFrom:  4,
+			wantFound: true,
+		},
+		{
+			name:   "one shared word is not an overlap",
+			prev:   "we use channels",
+			next:   "channels are typed",
+			window: 10,
+		},
+		{
+			name:   "shared words outside the window",
+			prev:   "alpha beta gamma delta epsilon",
+			next:   "alpha beta zeta",
+			window: 2,
+		},
+		{
+			name:   "empty next transcript",
+			prev:   "alpha beta",
+			next:   "",
+			window: 10,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cut, from, ok := alignWords(strings.Fields(tt.prev), strings.Fields(tt.next), tt.window)
+			if ok != tt.wantFound {
+				t.Fatalf("alignWords() ok = %v, want %v", ok, tt.wantFound)
+			}
+			if ok && (cut != tt.wantCut || from != tt.wantFrom) {
+				t.Errorf("alignWords() = (%d, %d), want (%d, %d)", cut, from, tt.wantCut, tt.wantFrom)
+			}
+		})
+	}
+}
+
+func TestStitchTranscripts(t *testing.T) {
+	tests := []struct {
+		name         string
+		chunks       []Chunk
+		transcripts  []models.Transcript
+		wantText     string
+		wantSegments models.TranscriptSegments
+	}{
+		{
+			name: "overlap kept once in the text and the segments",
+			chunks: []Chunk{
+				{Start: 0, Duration: 100},
+				{Start: 98, Duration: 50, Overlap: 2},
+			},
+			transcripts: []models.Transcript{
+				{
+					Text: "hello there how are you doing today",
+					Segments: models.TranscriptSegments{
+						{Start: 0, End: 50, Text: "hello there how are"},
+						{Start: 50, End: 99.5, Text: "you doing today"},
+					},
+				},
+				{
+					Text: "you doing today fine thanks",
+					Segments: models.TranscriptSegments{
+						{Start: 0, End: 0.5, Text: "you doing"},
+						{Start: 0.5, End: 1.5, Text: "today"},
+						{Start: 1.5, End: 10, Text: "fine thanks"},
+					},
+				},
+			},
+			wantText: "hello there how are you doing today fine thanks",
+			wantSegments: models.TranscriptSegments{
+				{Start: 0, End: 50, Text: "hello there how are"},
+				{Start: 50, End: 99.5, Text: "you doing today"},
+				{Start: 99.5, End: 108, Text: "fine thanks"},
+			},
+		},
+		{
+			name: "words after the repeated run are taken from the later chunk",
+			chunks: []Chunk{
+				{Start: 0, Duration: 10},
+				{Start: 8, Duration: 10, Overlap: 2},
+			},
+			transcripts: []models.Transcript{
+				{
+					Text: "a b c d e",
+					Segments: models.TranscriptSegments{
+						{Start: 0, End: 5, Text: "a b c"},
+						{Start: 5, End: 10, Text: "d e"},
+					},
+				},
+				{
+					Text: "c d x y",
+					Segments: models.TranscriptSegments{
+						{Start: 0, End: 2, Text: "c d x"},
+						{Start: 2, End: 10, Text: "y"},
+					},
+				},
+			},
+			wantText: "a b c d x y",
+			wantSegments: models.TranscriptSegments{
+				{Start: 0, End: 5, Text: "a b c"},
+				{Start: 5, End: 7.5, Text: "d"},
+				{Start: 28.0 / 3, End: 10, Text: "x"},
+				{Start: 10, End: 18, Text: "y"},
+			},
+		},
+		{
+			name: "chunks without segments get one from the kept words",
+			chunks: []Chunk{
+				{Start: 0, Duration: 10},
+				{Start: 8, Duration: 10, Overlap: 2},
+			},
+			transcripts: []models.Transcript{
+				{Text: "one two three four"},
+				{Text: "three four five six"},
+			},
+			wantText: "one two three four five six",
+			wantSegments: models.TranscriptSegments{
+				{Start: 0, End: 10, Text: "one two three four"},
+				{Start: 10, End: 18, Text: "five six"},
+			},
+		},
+		{
+			name: "unaligned overlap keeps both texts with the later timing",
+			chunks: []Chunk{
+				{Start: 0, Duration: 10},
+				{Start: 8, Duration: 10, Overlap: 2},
+			},
+			transcripts: []models.Transcript{
+				{Text: "one two three"},
+				{Text: "four five six"},
+			},
+			wantText: "one two three four five six",
+			wantSegments: models.TranscriptSegments{
+				{Start: 0, End: 8, Text: "one two three"},
+				{Start: 8, End: 18, Text: "four five six"},
+			},
+		},
+		{
+			name: "overlap of the first chunk is ignored",
+			chunks: []Chunk{
+				{Start: 0, Duration: 10, Overlap: 2},
+			},
+			transcripts: []models.Transcript{
+				{Text: "only chunk"},
+			},
+			wantText: "only chunk",
+			wantSegments: models.TranscriptSegments{
+				{Start: 0, End: 10, Text: "only chunk"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := (&Parser{}).StitchTranscripts(tt.chunks, tt.transcripts)
+			if got.Text != tt.wantText {
+				t.Errorf("text = %q, want %q", got.Text, tt.wantText)
+			}
+			if len(got.Segments) != len(tt.wantSegments) {
+				t.Fatalf("segments = %+v, want %+v", got.Segments, tt.wantSegments)
+			}
+			for i, seg := range got.Segments {
+				want := tt.wantSegments[i]
+				if !closeTo(seg.Start, want.Start) || !closeTo(seg.End, want.End) || seg.Text != want.Text {
+					t.Errorf("segment %d = %+v, want %+v", i, seg, want)
+				}
+			}
+		})
+	}
+}
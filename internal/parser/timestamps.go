@@ -0,0 +1,135 @@
+package parser
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/mrbelka12000/interview_parser/internal/models"
+)
+
+const (
+	// matchWords is how many words of a question or an answer are looked up in the transcript
+	matchWords = 8
+	// minMatchRatio is the share of looked up words that must be found for a match
+	minMatchRatio = 0.5
+)
+
+type segmentWord struct {
+	word    string
+	segment int
+}
+
+// LinkTimestamps sets StartTime to the beginning of the question and EndTime to the end of
+// the answer for every question answer found in the timed segments.
+// When the segments are labelled, Questioner and Answerer get the speaker names.
+// The analysis model may rephrase the text slightly, so words are matched fuzzily.
+func (p *Parser) LinkTimestamps(qaList []models.QuestionAnswer, segments models.TranscriptSegments) {
+	if len(segments) == 0 {
+		return
+	}
+
+	var words []segmentWord
+	for i, seg := range segments {
+		for _, w := range splitWords(seg.Text) {
+			words = append(words, segmentWord{word: w, segment: i})
+		}
+	}
+
+	var from int
+	for i := range qaList {
+		qa := &qaList[i]
+
+		question := splitWords(qa.Question)
+		if len(question) > matchWords {
+			question = question[:matchWords]
+		}
+
+		qStart, qEnd, ok := findWords(words, question, from)
+		if !ok {
+			// questions usually come in order, but fall back to the whole transcript
+			qStart, qEnd, ok = findWords(words, question, 0)
+		}
+		if !ok {
+			continue
+		}
+
+		start := segments[words[qStart].segment].Start
+		end := segments[words[qEnd].segment].End
+		if speaker := segments[words[qStart].segment].Speaker; speaker != "" {
+			qa.Questioner = speaker
+		}
+
+		answer := splitWords(qa.FullAnswer)
+		if len(answer) > matchWords {
+			answer = answer[len(answer)-matchWords:]
+		}
+		if aStart, aEnd, ok := findWords(words, answer, qEnd+1); ok {
+			end = segments[words[aEnd].segment].End
+			from = aEnd + 1
+			if speaker := segments[words[aStart].segment].Speaker; speaker != "" {
+				qa.Answerer = speaker
+			}
+		} else {
+			from = qEnd + 1
+		}
+
+		qa.StartTime = &start
+		qa.EndTime = &end
+	}
+}
+
+// findWords returns the positions of the first and the last matched word of the best
+// window starting at or after from
+func findWords(words []segmentWord, query []string, from int) (first, last int, ok bool) {
+	if len(query) == 0 {
+		return 0, 0, false
+	}
+
+	need := make(map[string]int, len(query))
+	for _, w := range query {
+		need[w]++
+	}
+
+	bestScore := 0
+	for i := from; i < len(words); i++ {
+		if need[words[i].word] == 0 {
+			continue
+		}
+
+		var (
+			score     int
+			lastMatch int
+			left      = make(map[string]int, len(need))
+		)
+		for w, n := range need {
+			left[w] = n
+		}
+		for j := i; j < len(words) && j < i+len(query); j++ {
+			if left[words[j].word] > 0 {
+				left[words[j].word]--
+				score++
+				lastMatch = j
+			}
+		}
+
+		if score > bestScore {
+			bestScore, first, last = score, i, lastMatch
+			if score == len(query) {
+				break
+			}
+		}
+	}
+
+	if float64(bestScore) < float64(len(query))*minMatchRatio {
+		return 0, 0, false
+	}
+
+	return first, last, true
+}
+
+// splitWords lowercases the text and splits it into words without punctuation
+func splitWords(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
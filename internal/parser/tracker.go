@@ -0,0 +1,109 @@
+package parser
+
+import (
+	"encoding/binary"
+	"math"
+
+	"github.com/mrbelka12000/interview_parser/internal/models"
+)
+
+const (
+	// speakerWindowSeconds is the resolution of the speaker timeline
+	speakerWindowSeconds = 0.5
+	// speakerSilenceRMS is the loudness (of 32768) below which nobody is considered speaking
+	speakerSilenceRMS = 500
+	// speakerDominance is how many times one stream must be louder than the other to tell who speaks
+	speakerDominance = 2
+)
+
+// SpeakerTracker builds a speaker timeline by comparing the loudness of the mic and the system streams:
+// the mic carries the user, the system audio carries the other side of the call
+type SpeakerTracker struct {
+	sampleRate uint32
+	channels   uint32
+
+	position  float64
+	window    float64
+	micEnergy float64
+	sysEnergy float64
+	samples   int
+
+	turns []models.SpeakerTurn
+}
+
+// NewSpeakerTracker creates a tracker for 16-bit PCM streams of the given format
+func NewSpeakerTracker(sampleRate, channels uint32) *SpeakerTracker {
+	return &SpeakerTracker{
+		sampleRate: sampleRate,
+		channels:   channels,
+	}
+}
+
+// Add accounts a pair of 16-bit PCM buffers captured at the same time
+func (t *SpeakerTracker) Add(mic, sys []byte) {
+	frames := max(len(mic), len(sys)) / 2 / int(t.channels)
+	if frames == 0 {
+		return
+	}
+
+	t.micEnergy += energy(mic)
+	t.sysEnergy += energy(sys)
+	t.samples += frames * int(t.channels)
+	t.window += float64(frames) / float64(t.sampleRate)
+
+	if t.window >= speakerWindowSeconds {
+		t.Flush()
+	}
+}
+
+// Flush closes the current window and extends the timeline with its speaker
+func (t *SpeakerTracker) Flush() {
+	if t.window == 0 {
+		return
+	}
+
+	var (
+		micRMS  = math.Sqrt(t.micEnergy / float64(t.samples))
+		sysRMS  = math.Sqrt(t.sysEnergy / float64(t.samples))
+		speaker string
+	)
+	switch {
+	case max(micRMS, sysRMS) < speakerSilenceRMS:
+	case micRMS > sysRMS*speakerDominance:
+		speaker = models.SpeakerMe
+	case sysRMS > micRMS*speakerDominance:
+		speaker = models.SpeakerRemote
+	}
+
+	start, end := t.position, t.position+t.window
+	t.position = end
+	t.window, t.micEnergy, t.sysEnergy, t.samples = 0, 0, 0, 0
+
+	if speaker == "" {
+		return
+	}
+
+	// a window continuing the previous turn of the same speaker extends it
+	if n := len(t.turns); n > 0 && t.turns[n-1].Speaker == speaker && t.turns[n-1].End == start {
+		t.turns[n-1].End = end
+		return
+	}
+	t.turns = append(t.turns, models.SpeakerTurn{Start: start, End: end, Speaker: speaker})
+}
+
+// Turns returns a copy of the timeline built so far
+func (t *SpeakerTracker) Turns() []models.SpeakerTurn {
+	turns := make([]models.SpeakerTurn, len(t.turns))
+	copy(turns, t.turns)
+	return turns
+}
+
+// energy returns the sum of squared 16-bit samples
+func energy(buf []byte) float64 {
+	var sum float64
+	for i := 0; i+1 < len(buf); i += 2 {
+		s := float64(int16(binary.LittleEndian.Uint16(buf[i:])))
+		sum += s * s
+	}
+	return sum
+}
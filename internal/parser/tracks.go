@@ -0,0 +1,95 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mrbelka12000/interview_parser/internal/models"
+	"github.com/mrbelka12000/interview_parser/internal/wav"
+)
+
+const (
+	micTrackSuffix    = "_mic.wav"
+	systemTrackSuffix = "_system.wav"
+
+	// trackReadFrames is how many frames of every track are compared at once
+	trackReadFrames = 4800
+)
+
+// TrackPaths returns the paths of the mic and the system audio tracks of a recording
+func (p *Parser) TrackPaths(mediaPath string) (micPath, systemPath string) {
+	base := strings.TrimSuffix(mediaPath, filepath.Ext(mediaPath))
+	return base + micTrackSuffix, base + systemTrackSuffix
+}
+
+// RecordingFiles returns the media file with the files saved next to it by the recorder
+func (p *Parser) RecordingFiles(mediaPath string) []string {
+	micPath, systemPath := p.TrackPaths(mediaPath)
+	return []string{mediaPath, mediaPath + speakersFileSuffix, mediaPath + pausesFileSuffix, micPath, systemPath}
+}
+
+// SpeakerTurnsFromTracks builds the speaker timeline from the separate mic and system tracks of a recording.
+// Recordings without tracks return no turns and no error.
+func (p *Parser) SpeakerTurnsFromTracks(mediaPath string) ([]models.SpeakerTurn, error) {
+	micPath, systemPath := p.TrackPaths(mediaPath)
+
+	mic, err := wav.OpenReader(micPath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to open mic track: %w", err)
+	}
+	defer mic.Close()
+
+	system, err := wav.OpenReader(systemPath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to open system track: %w", err)
+	}
+	defer system.Close()
+
+	if mic.SampleRate != system.SampleRate || mic.Channels != system.Channels || mic.BitsPerSample != 16 || system.BitsPerSample != 16 {
+		return nil, errors.New("mic and system tracks have different or unsupported formats")
+	}
+
+	var (
+		tracker = NewSpeakerTracker(mic.SampleRate, uint32(mic.Channels))
+		size    = trackReadFrames * int(mic.Channels) * 2
+		micBuf  = make([]byte, size)
+		sysBuf  = make([]byte, size)
+	)
+	for {
+		micN, micErr := io.ReadFull(mic, micBuf)
+		sysN, sysErr := io.ReadFull(system, sysBuf)
+		if micN == 0 && sysN == 0 {
+			break
+		}
+
+		tracker.Add(micBuf[:micN], sysBuf[:sysN])
+
+		if err = readError(micErr); err != nil {
+			return nil, fmt.Errorf("failed to read mic track: %w", err)
+		}
+		if err = readError(sysErr); err != nil {
+			return nil, fmt.Errorf("failed to read system track: %w", err)
+		}
+	}
+	tracker.Flush()
+
+	return tracker.Turns(), nil
+}
+
+// readError drops the errors reporting the end of the data
+func readError(err error) error {
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil
+	}
+	return err
+}
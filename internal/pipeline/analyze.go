@@ -0,0 +1,169 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/mrbelka12000/interview_parser/internal/client"
+	"github.com/mrbelka12000/interview_parser/internal/models"
+)
+
+// AnalyzeInterview extracts question/answer pairs from the transcript and saves the interview.
+// Question answers are linked to the transcript segments and their speakers when the transcript has timings.
+// When only some batches fail, the interview is saved without them and returned with a *PartialError.
+// The transcript is analyzed in lang, auto takes the configured language or detects it from the transcript.
+func (p *Pipeline) AnalyzeInterview(ctx context.Context, transcript *models.Transcript, lang models.Language) (*models.AnalyzeInterviewWithQA, error) {
+	p.report(ctx, 76, "Identifying speakers...", "Splitting the transcript into speaker turns...")
+	p.labelSpeakers(ctx, transcript, interviewSpeakers)
+
+	transcriptBatches := p.parser.BatchTranscript(transcript.Text)
+	analyzeRespBatches := make([][]models.QuestionAnswer, len(transcriptBatches))
+	promptVersions := make([]string, len(transcriptBatches))
+	// the whole transcript tells the language better than any of its batches
+	lang = p.cfg.ResolveLanguage(lang, transcript.Text)
+	errs := p.analyzeBatches(ctx, transcriptBatches, lang, client.AnalysisOptions{}, analyzeRespBatches, promptVersions, nil, nil)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if err := budgetError(errs); err != nil {
+		return nil, err
+	}
+
+	var failures []models.JobFailure
+	for i, err := range errs {
+		if err != nil {
+			failures = append(failures, models.JobFailure{Index: i, Error: err.Error()})
+		}
+	}
+	if len(failures) == len(transcriptBatches) {
+		return nil, fmt.Errorf("failed to analyze all %d transcript batches: %s", len(failures), failures[0].Error)
+	}
+
+	interview, err := p.saveInterview(ctx, transcript, analyzeRespBatches, promptVersions)
+	if err != nil {
+		return nil, err
+	}
+	if len(failures) > 0 {
+		return interview, &PartialError{Batches: failures}
+	}
+
+	return interview, nil
+}
+
+// analyzeBatches analyzes the transcript batches in lang with the options in parallel into results and the prompt
+// versions used into versions, skipping the ones marked done, and returns the errors of the batches that failed
+// after all retries. onDone is called for every attempted batch while no other batch is being collected.
+func (p *Pipeline) analyzeBatches(ctx context.Context, batches []string, lang models.Language, opts client.AnalysisOptions, results [][]models.QuestionAnswer, versions []string, done []bool, onDone func(ind int, err error)) []error {
+	p.report(ctx, 78, "Starting analyzing...", "Analyzing transcripts...")
+	var (
+		wg        sync.WaitGroup
+		mx        sync.Mutex
+		aiClient  = p.client()
+		workers   = make(chan struct{}, p.cfg.ParallelWorkers)
+		completed = 0
+		errs      = make([]error, len(batches))
+	)
+
+	for i, batch := range batches {
+		if i < len(done) && done[i] {
+			completed++
+			continue
+		}
+
+		wg.Add(1)
+		workers <- struct{}{}
+		go func(ind int, b string) {
+			defer func() {
+				<-workers
+				wg.Done()
+			}()
+
+			analyzeRespTmp, err := aiClient.AnalyzeTranscript(ctx, b, lang, opts)
+
+			mx.Lock()
+			defer mx.Unlock()
+
+			if onDone != nil {
+				defer onDone(ind, err)
+			}
+			if err != nil {
+				log.Printf("Error analyzing transcript %d: %v", ind, err)
+				errs[ind] = err
+				return
+			}
+
+			results[ind] = analyzeRespTmp.QA
+			versions[ind] = analyzeRespTmp.PromptVersion
+			completed++
+			progress := 85 + int(float64(completed)/float64(len(batches))*10) // 85% to 95%
+
+			p.report(ctx, progress, "Analyzing transcript...", fmt.Sprintf("Analyzed %d/%d tranascript segments...", completed, len(batches)))
+		}(i, batch)
+	}
+
+	wg.Wait()
+	close(workers)
+
+	return errs
+}
+
+// saveInterview joins the question answers of the batches, links them to the transcript segments and saves the interview
+// with the prompt versions the batches were analyzed with
+func (p *Pipeline) saveInterview(ctx context.Context, transcript *models.Transcript, batches [][]models.QuestionAnswer, versions []string) (*models.AnalyzeInterviewWithQA, error) {
+	analyzeResp := models.AnalyzeInterviewWithQA{
+		Transcript:    transcript.Text,
+		Segments:      transcript.Segments,
+		PromptVersion: joinVersions(versions),
+	}
+	for _, batch := range batches {
+		analyzeResp.QA = append(analyzeResp.QA, batch...)
+	}
+	p.parser.LinkTimestamps(analyzeResp.QA, transcript.Segments)
+
+	// Step 6: Save analysis response
+	p.report(ctx, 97, "Saving analysis...", "Writing analysis file...")
+	if err := p.service.SaveInterview(&analyzeResp); err != nil {
+		return nil, fmt.Errorf("failed to save analysis: %w", err)
+	}
+
+	return &analyzeResp, nil
+}
+
+// AnalyzeCall analyzes the meeting transcript in lang and saves the call, auto takes the configured language
+// or detects it from the transcript
+func (p *Pipeline) AnalyzeCall(ctx context.Context, transcript *models.Transcript, lang models.Language) (*models.Call, error) {
+	p.report(ctx, 70, "Identifying speakers...", "Splitting the transcript into speaker turns...")
+	p.labelSpeakers(ctx, transcript, nil)
+
+	p.report(ctx, 75, "Analyzing call...", "Analyzing meeting content...")
+	analyzeCallResp, err := p.client().AnalyzeCall(ctx, transcript.Text, lang)
+	if err != nil {
+		return nil, fmt.Errorf("faield to analyze call: %w", err)
+	}
+	analyzeCallResp.Segments = transcript.Segments
+
+	// Step 5: Save analysis response
+	p.report(ctx, 90, "Saving analysis...", "Writing analysis file...")
+	call, err := p.service.SaveCall(analyzeCallResp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save call: %w", err)
+	}
+
+	return call, nil
+}
+
+// joinVersions lists the distinct prompt versions, a transcript re-analyzed after a prompt edit can have several
+func joinVersions(versions []string) string {
+	var distinct []string
+	for _, version := range versions {
+		if version != "" && !slices.Contains(distinct, version) {
+			distinct = append(distinct, version)
+		}
+	}
+
+	return strings.Join(distinct, ", ")
+}
@@ -0,0 +1,65 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/mrbelka12000/interview_parser/internal/client"
+	"github.com/mrbelka12000/interview_parser/internal/models"
+)
+
+// EstimateCost estimates the cost of processing the media file as a job of the type, from its duration
+func (p *Pipeline) EstimateCost(ctx context.Context, jobType models.JobType, filePath string) (*models.CostEstimate, error) {
+	duration, err := p.parser.Duration(ctx, filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read media duration: %w", err)
+	}
+
+	estimate := client.EstimateCost(p.cfg, jobType, duration)
+	return &estimate, nil
+}
+
+// checkBudget refuses to run the job when the estimated cost of what is left of it would take the job
+// or the month over the spending limits
+func (p *Pipeline) checkBudget(ctx context.Context, job *models.Job) error {
+	if p.cfg.SpendingMonthlyLimit <= 0 && p.cfg.SpendingJobLimit <= 0 {
+		return nil
+	}
+
+	var duration, pending float64
+	for _, chunk := range job.Chunks {
+		duration += chunk.Duration
+		if !chunk.Done {
+			pending += chunk.Duration
+		}
+	}
+	if len(job.Chunks) == 0 {
+		var err error
+		if duration, err = p.parser.Duration(ctx, job.FilePath); err != nil {
+			// the file is checked again when it is split
+			log.Printf("[I] Failed to estimate the cost of job %d: %v\n", job.ID, err)
+		}
+		pending = duration
+	}
+
+	estimate := client.EstimateCost(p.cfg, job.Type, duration)
+	cost := estimate.Diarize + estimate.Analyze
+	if duration > 0 {
+		cost += estimate.Transcribe * pending / duration
+	}
+
+	return client.CheckBudget(&p.cfg.LimitsConfig, p.service, job.ID, cost)
+}
+
+// budgetError returns the first error of the requests refused by the spending limits
+func budgetError(errs []error) error {
+	for _, err := range errs {
+		if errors.Is(err, client.ErrBudgetExceeded) {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,78 @@
+package pipeline
+
+import (
+	"context"
+	"log"
+	"sync"
+
+	"github.com/mrbelka12000/interview_parser/internal/models"
+)
+
+// diarizeBatchChars limits the transcript text sent in one diarization request
+const diarizeBatchChars = 6000
+
+// interviewSpeakers are the names given to the sides of an interview by diarization
+var interviewSpeakers = []string{"Interviewer", "Candidate"}
+
+// labelSpeakers splits the transcript segments into speaker turns with the diarization model,
+// unless they are labelled already, e.g. from the timeline of a recording made in the app.
+// Diarization is best effort: failed batches leave their segments unlabelled.
+func (p *Pipeline) labelSpeakers(ctx context.Context, transcript *models.Transcript, speakers []string) {
+	if !p.cfg.Diarize || len(transcript.Segments) == 0 || transcript.Segments.HasSpeakers() {
+		return
+	}
+
+	var (
+		batches [][2]int
+		from    int
+		size    int
+	)
+	for i, seg := range transcript.Segments {
+		if size > 0 && size+len(seg.Text) > diarizeBatchChars {
+			batches = append(batches, [2]int{from, i})
+			from, size = i, 0
+		}
+		size += len(seg.Text)
+	}
+	batches = append(batches, [2]int{from, len(transcript.Segments)})
+
+	var (
+		wg       sync.WaitGroup
+		aiClient = p.client()
+		workers  = make(chan struct{}, p.cfg.ParallelWorkers)
+		parts    = make([]models.TranscriptSegments, len(transcript.Segments))
+	)
+
+	for i, batch := range batches {
+		wg.Add(1)
+		workers <- struct{}{}
+		go func(ind int, b [2]int) {
+			defer func() {
+				<-workers
+				wg.Done()
+			}()
+
+			batchParts, err := aiClient.Diarize(ctx, transcript.Segments[b[0]:b[1]], speakers)
+			if err != nil {
+				log.Printf("Error diarizing batch %d: %v", ind, err)
+				return
+			}
+
+			// batches do not overlap, so no lock is needed
+			copy(parts[b[0]:b[1]], batchParts)
+		}(i, batch)
+	}
+
+	wg.Wait()
+
+	labelled := make(models.TranscriptSegments, 0, len(transcript.Segments))
+	for i, seg := range transcript.Segments {
+		if len(parts[i]) == 0 {
+			labelled = append(labelled, seg)
+			continue
+		}
+		labelled = append(labelled, p.parser.SplitByText(seg, parts[i])...)
+	}
+
+	transcript.Segments = labelled
+}
@@ -0,0 +1,44 @@
+package pipeline
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mrbelka12000/interview_parser/internal/models"
+	"github.com/mrbelka12000/interview_parser/internal/parser"
+)
+
+// PartialError reports the chunks and batches that failed after all retries while the others succeeded,
+// the result is usable but misses their part of the recording
+type PartialError struct {
+	Chunks  []models.JobFailure
+	Batches []models.JobFailure
+}
+
+func (e *PartialError) Error() string {
+	var parts []string
+	for _, f := range e.Chunks {
+		parts = append(parts, fmt.Sprintf("chunk %d (%s-%s): %s", f.Index, formatSeconds(f.Start), formatSeconds(f.End), f.Error))
+	}
+	for _, f := range e.Batches {
+		parts = append(parts, fmt.Sprintf("batch %d: %s", f.Index, f.Error))
+	}
+
+	return fmt.Sprintf("%d chunks and %d batches failed: %s", len(e.Chunks), len(e.Batches), strings.Join(parts, "; "))
+}
+
+// chunkFailure describes a chunk that failed to transcribe
+func chunkFailure(ind int, chunk parser.Chunk, err error) models.JobFailure {
+	return models.JobFailure{
+		Index: ind,
+		Start: chunk.Start,
+		End:   chunk.Start + chunk.Duration,
+		Error: err.Error(),
+	}
+}
+
+// formatSeconds formats a position in the recording as m:ss
+func formatSeconds(seconds float64) string {
+	total := int(seconds)
+	return fmt.Sprintf("%d:%02d", total/60, total%60)
+}
@@ -0,0 +1,574 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/mrbelka12000/interview_parser/internal/client"
+	"github.com/mrbelka12000/interview_parser/internal/models"
+	"github.com/mrbelka12000/interview_parser/internal/parser"
+)
+
+// jobChunksPrefix names the chunk directory of every job in the chunks directory
+const jobChunksPrefix = "job_"
+
+// ErrJobCancelled is returned by a job stopped with CancelJob
+var ErrJobCancelled = errors.New("job was cancelled")
+
+// runningJob is a job running in this process
+type runningJob struct {
+	ctx       context.Context
+	cancel    context.CancelFunc
+	cancelled bool
+	done      chan struct{}
+}
+
+// StartJob creates a job processing the media file and runs it. The job state is saved after every
+// transcribed chunk and analyzed batch, so an interrupted job continues from there when it is run again.
+// The formatted transcript of interview jobs is also written to transcriptPath when it is set.
+// The transcript is analyzed in lang, auto takes the configured language or detects it from the transcript.
+func (p *Pipeline) StartJob(ctx context.Context, jobType models.JobType, filePath, transcriptPath string, lang models.Language) (*models.Job, error) {
+	job, err := p.service.CreateJob(jobType, filePath, transcriptPath, lang)
+	if err != nil {
+		return nil, err
+	}
+
+	return job, p.runJob(ctx, job)
+}
+
+// StartJobInBackground creates a job like StartJob and runs it in the background on ctx, which has to outlive
+// the caller, e.g. the request starting the job. finished is called with the result of the job.
+// The returned job is its state at the start, the progress is read from the store.
+func (p *Pipeline) StartJobInBackground(ctx context.Context, jobType models.JobType, filePath, transcriptPath string, lang models.Language, finished func(job *models.Job, err error)) (*models.Job, error) {
+	job, err := p.service.CreateJob(jobType, filePath, transcriptPath, lang)
+	if err != nil {
+		return nil, err
+	}
+
+	return job, p.runJobInBackground(ctx, job.ID, finished)
+}
+
+// RunJob runs a job that did not complete again, continuing from its last completed chunk or batch
+func (p *Pipeline) RunJob(ctx context.Context, id uint64) (*models.Job, error) {
+	job, err := p.service.GetJob(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if job.Status == models.JobStatusCompleted {
+		return job, fmt.Errorf("job %d is already completed", id)
+	}
+
+	return job, p.runJob(ctx, job)
+}
+
+// RunJobInBackground runs a job that did not complete again like RunJob, in the background on ctx.
+// finished is called with the result of the job. The returned job is its state at the start.
+func (p *Pipeline) RunJobInBackground(ctx context.Context, id uint64, finished func(job *models.Job, err error)) (*models.Job, error) {
+	job, err := p.service.GetJob(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if job.Status == models.JobStatusCompleted {
+		return job, fmt.Errorf("job %d is already completed", id)
+	}
+
+	return job, p.runJobInBackground(ctx, job.ID, finished)
+}
+
+// ResumeJobs runs the jobs left unfinished when the app was closed, one by one.
+// The chunk directories of the other jobs and of interrupted runs are removed first.
+// finished is called with the result of every job.
+func (p *Pipeline) ResumeJobs(ctx context.Context, finished func(job *models.Job, err error)) {
+	jobs, err := p.service.GetUnfinishedJobs()
+	if err != nil {
+		log.Printf("[I] Failed to load unfinished jobs: %v\n", err)
+		return
+	}
+	p.cleanChunks(jobs)
+
+	for i := range jobs {
+		if ctx.Err() != nil {
+			return
+		}
+
+		job := &jobs[i]
+		log.Printf("[I] Resuming %s job %d of %s\n", job.Type, job.ID, job.FilePath)
+
+		err = p.runJob(ctx, job)
+		if err != nil {
+			log.Printf("[I] Job %d did not complete: %v\n", job.ID, err)
+		}
+		if finished != nil {
+			finished(job, err)
+		}
+	}
+}
+
+// CancelJob stops the job if it is running and marks it cancelled, so it is not resumed anymore.
+// The running ffmpeg processes and API requests of the job are interrupted and its chunk directory removed.
+func (p *Pipeline) CancelJob(id uint64) (*models.Job, error) {
+	p.jobsMx.Lock()
+	run, ok := p.running[id]
+	if ok {
+		run.cancelled = true
+		run.cancel()
+	}
+	p.jobsMx.Unlock()
+
+	if ok {
+		// the job saves its cancelled state when it stops
+		<-run.done
+		return p.service.GetJob(id)
+	}
+
+	job, err := p.service.GetJob(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if job.Status == models.JobStatusCompleted || job.Status == models.JobStatusCancelled {
+		return job, fmt.Errorf("job %d is already %s", id, job.Status)
+	}
+
+	job.Status = models.JobStatusCancelled
+	if err = p.service.UpdateJob(job); err != nil {
+		return nil, err
+	}
+	p.removeChunks(job.ID)
+
+	return job, nil
+}
+
+// runJob runs the job and saves its final state
+func (p *Pipeline) runJob(parent context.Context, job *models.Job) error {
+	run, err := p.trackJob(parent, job.ID)
+	if err != nil {
+		return err
+	}
+
+	return p.processJob(parent, run, job)
+}
+
+// runJobInBackground starts the job in a goroutine and calls finished with its result. The job is tracked
+// as running before it returns, so it can be cancelled right away.
+func (p *Pipeline) runJobInBackground(parent context.Context, jobID uint64, finished func(job *models.Job, err error)) error {
+	// the job of the caller is not shared with the goroutine updating it
+	job, err := p.service.GetJob(jobID)
+	if err != nil {
+		return err
+	}
+
+	run, err := p.trackJob(parent, jobID)
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		err := p.processJob(parent, run, job)
+		if finished != nil {
+			finished(job, err)
+		}
+	}()
+
+	return nil
+}
+
+// trackJob registers the job as running in this process, it fails when the job is already running
+func (p *Pipeline) trackJob(parent context.Context, jobID uint64) (*runningJob, error) {
+	ctx, cancel := context.WithCancel(client.WithJob(parent, jobID))
+	run := &runningJob{ctx: ctx, cancel: cancel, done: make(chan struct{})}
+
+	p.jobsMx.Lock()
+	defer p.jobsMx.Unlock()
+
+	if _, ok := p.running[jobID]; ok {
+		cancel()
+		return nil, fmt.Errorf("job %d is already running", jobID)
+	}
+	p.running[jobID] = run
+
+	return run, nil
+}
+
+// processJob runs the tracked job and saves its final state: completed, partial when some chunks or batches failed,
+// paused when the spending limits stopped it or parent was cancelled, e.g. when the app is closing, failed or cancelled.
+// Paused jobs are resumed on the next start.
+func (p *Pipeline) processJob(parent context.Context, run *runningJob, job *models.Job) (err error) {
+	ctx := run.ctx
+
+	defer func() {
+		p.jobsMx.Lock()
+		delete(p.running, job.ID)
+		cancelled := run.cancelled
+		p.jobsMx.Unlock()
+		run.cancel()
+
+		var partial *PartialError
+		switch {
+		case cancelled:
+			job.Status, job.Error = models.JobStatusCancelled, ""
+			err = ErrJobCancelled
+		case errors.Is(err, client.ErrBudgetExceeded):
+			job.Status, job.Error = models.JobStatusPaused, err.Error()
+		case errors.As(err, &partial):
+			job.Status, job.Error = models.JobStatusPartial, err.Error()
+		case err != nil && parent.Err() != nil:
+			// the job did not fail, it was interrupted
+			job.Status, job.Error = models.JobStatusPaused, err.Error()
+		case err != nil:
+			job.Status, job.Error = models.JobStatusFailed, err.Error()
+		default:
+			job.Status, job.Error = models.JobStatusCompleted, ""
+		}
+
+		if saveErr := p.service.UpdateJob(job); saveErr != nil {
+			log.Printf("[I] Failed to save job %d: %v\n", job.ID, saveErr)
+		}
+		if job.Status != models.JobStatusRunning && job.Status != models.JobStatusPaused {
+			// a retry splits the file again, only an interrupted or paused job resumes with its chunks
+			p.removeChunks(job.ID)
+		}
+		close(run.done)
+	}()
+
+	if err = p.checkBudget(ctx, job); err != nil {
+		return err
+	}
+
+	job.Status, job.Error = models.JobStatusRunning, ""
+	if err = p.service.UpdateJob(job); err != nil {
+		return err
+	}
+
+	// a new transcript is analyzed in new batches, the results of the batches with the same text are kept
+	previous := job.Batches
+
+	transcript, changed, err := p.jobTranscript(ctx, job)
+	if err != nil {
+		return err
+	}
+
+	if job.Language.IsAuto() {
+		job.Language = p.cfg.ResolveLanguage(job.Language, transcript.Text)
+		if err = p.service.UpdateJob(job); err != nil {
+			return err
+		}
+	}
+
+	switch job.Type {
+	case models.JobTypeInterview:
+		err = p.jobInterview(ctx, job, transcript, previous)
+	case models.JobTypeCall:
+		err = p.jobCall(ctx, job, transcript, changed)
+	default:
+		err = fmt.Errorf("unknown job type: %q", job.Type)
+	}
+	if err != nil {
+		return err
+	}
+
+	if chunks, batches := job.FailedChunks(), job.FailedBatches(); len(chunks) > 0 || len(batches) > 0 {
+		return &PartialError{Chunks: chunks, Batches: batches}
+	}
+
+	return nil
+}
+
+// jobTranscript returns the transcript of the job, transcribing the chunks that are not transcribed yet.
+// A saved transcript missing failed chunks is built again when any of them succeeds now, changed reports that.
+func (p *Pipeline) jobTranscript(ctx context.Context, job *models.Job) (transcript *models.Transcript, changed bool, err error) {
+	if job.Transcript != nil {
+		saved := models.Transcript(*job.Transcript)
+		transcript = &saved
+
+		if len(job.FailedChunks()) == 0 {
+			return transcript, false, nil
+		}
+	}
+
+	chunks, err := p.jobChunks(ctx, job)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var (
+		collected   = make([]models.Transcript, len(chunks))
+		done        = make([]bool, len(chunks))
+		transcribed int
+	)
+	for i, chunk := range job.Chunks {
+		collected[i], done[i] = chunk.Transcript, chunk.Done
+	}
+
+	errs := p.transcribeChunks(ctx, chunks, job.Language, collected, done, func(ind int, err error) {
+		switch {
+		case err == nil:
+			job.Chunks[ind].Done = true
+			job.Chunks[ind].Transcript = collected[ind]
+			job.Chunks[ind].Error = ""
+			transcribed++
+		case ctx.Err() != nil, errors.Is(err, client.ErrBudgetExceeded):
+			// the chunk did not fail, the job was stopped
+			return
+		default:
+			job.Chunks[ind].Error = err.Error()
+		}
+
+		if err := p.service.UpdateJob(job); err != nil {
+			log.Printf("[I] Failed to save job %d: %v\n", job.ID, err)
+		}
+	})
+	if err = ctx.Err(); err != nil {
+		return nil, false, err
+	}
+	if err = budgetError(errs); err != nil {
+		return nil, false, err
+	}
+
+	if transcript != nil && transcribed == 0 {
+		// the failed chunks failed again, the saved transcript is still the best one
+		return transcript, false, nil
+	}
+
+	if failed := job.FailedChunks(); len(failed) == len(job.Chunks) {
+		return nil, false, fmt.Errorf("failed to transcribe all %d chunks: %s", len(failed), failed[0].Error)
+	}
+
+	transcript = p.joinTranscript(job.FilePath, chunks, collected)
+
+	if job.Type == models.JobTypeInterview {
+		transcript.Text = p.parser.FormatText(transcript.Text)
+
+		if job.TranscriptPath != "" {
+			// Step 4: Save transcript
+			p.report(ctx, 75, "Saving transcript...", "Writing transcript file...")
+			if err = p.parser.SaveTranscript(job.TranscriptPath, transcript.Text); err != nil {
+				return nil, false, fmt.Errorf("failed to save transcript: %w", err)
+			}
+		}
+	}
+
+	saved := models.JobTranscript(*transcript)
+	job.Transcript = &saved
+	job.Batches = nil
+
+	return transcript, true, p.service.UpdateJob(job)
+}
+
+// jobChunks returns the chunks of the job. The media file is split again when the files of chunks
+// still to transcribe are missing; the transcripts of chunks that did not change are kept.
+func (p *Pipeline) jobChunks(ctx context.Context, job *models.Job) ([]parser.Chunk, error) {
+	if len(job.Chunks) > 0 && pendingChunksExist(job.Chunks) {
+		chunks := make([]parser.Chunk, len(job.Chunks))
+		for i, chunk := range job.Chunks {
+			chunks[i] = parser.Chunk{Path: chunk.Path, Start: chunk.Start, Duration: chunk.Duration, Overlap: chunk.Overlap}
+		}
+		return chunks, nil
+	}
+
+	chunks, err := p.splitFile(ctx, job.FilePath, p.chunksDir(job.ID))
+	if err != nil {
+		return nil, err
+	}
+
+	previous := job.Chunks
+	job.Chunks = make(models.JobChunks, len(chunks))
+	for i, chunk := range chunks {
+		job.Chunks[i] = models.JobChunk{Path: chunk.Path, Start: chunk.Start, Duration: chunk.Duration, Overlap: chunk.Overlap}
+
+		if i < len(previous) && previous[i].Start == chunk.Start && previous[i].Duration == chunk.Duration {
+			job.Chunks[i].Done = previous[i].Done
+			job.Chunks[i].Transcript = previous[i].Transcript
+		}
+	}
+
+	return chunks, p.service.UpdateJob(job)
+}
+
+// jobInterview analyzes the transcript batches of the job that are not analyzed yet and saves the interview.
+// Batches of a new transcript reuse the results of the previous batches with the same text.
+func (p *Pipeline) jobInterview(ctx context.Context, job *models.Job, transcript *models.Transcript, previous models.JobBatches) error {
+	rebuilt := job.Batches == nil
+	if rebuilt {
+		p.report(ctx, 76, "Identifying speakers...", "Splitting the transcript into speaker turns...")
+		p.labelSpeakers(ctx, transcript, interviewSpeakers)
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		saved := models.JobTranscript(*transcript)
+		job.Transcript = &saved
+
+		analyzed := make(map[string]models.JobBatch, len(previous))
+		for _, batch := range previous {
+			if batch.Done {
+				analyzed[batch.Text] = batch
+			}
+		}
+
+		job.Batches = models.JobBatches{}
+		for _, text := range p.parser.BatchTranscript(transcript.Text) {
+			batch := models.JobBatch{Text: text}
+			if prev, ok := analyzed[text]; ok {
+				batch.Done, batch.QA, batch.PromptVersion = true, prev.QA, prev.PromptVersion
+			}
+			job.Batches = append(job.Batches, batch)
+		}
+		if err := p.service.UpdateJob(job); err != nil {
+			return err
+		}
+	}
+
+	var (
+		batches  = make([]string, len(job.Batches))
+		results  = make([][]models.QuestionAnswer, len(job.Batches))
+		versions = make([]string, len(job.Batches))
+		done     = make([]bool, len(job.Batches))
+		analyzed int
+	)
+	for i, batch := range job.Batches {
+		batches[i], results[i], versions[i], done[i] = batch.Text, batch.QA, batch.PromptVersion, batch.Done
+	}
+
+	errs := p.analyzeBatches(ctx, batches, job.Language, client.AnalysisOptions{}, results, versions, done, func(ind int, err error) {
+		switch {
+		case err == nil:
+			job.Batches[ind].Done = true
+			job.Batches[ind].QA = results[ind]
+			job.Batches[ind].PromptVersion = versions[ind]
+			job.Batches[ind].Error = ""
+			analyzed++
+		case ctx.Err() != nil, errors.Is(err, client.ErrBudgetExceeded):
+			// the batch did not fail, the job was stopped
+			return
+		default:
+			job.Batches[ind].Error = err.Error()
+		}
+
+		if err := p.service.UpdateJob(job); err != nil {
+			log.Printf("[I] Failed to save job %d: %v\n", job.ID, err)
+		}
+	})
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if err := budgetError(errs); err != nil {
+		return err
+	}
+
+	if job.ResultID != 0 && !rebuilt && analyzed == 0 {
+		// nothing new since the interview was saved
+		return nil
+	}
+	if failed := job.FailedBatches(); len(failed) == len(job.Batches) {
+		return fmt.Errorf("failed to analyze all %d transcript batches: %s", len(failed), failed[0].Error)
+	}
+
+	interview, err := p.saveInterview(ctx, transcript, results, versions)
+	if err != nil {
+		return err
+	}
+
+	p.replaceResult(job, interview.ID, p.service.DeleteInterview)
+	return nil
+}
+
+// jobCall analyzes the transcript of the job as a call, unless the call was saved from the same transcript
+func (p *Pipeline) jobCall(ctx context.Context, job *models.Job, transcript *models.Transcript, changed bool) error {
+	if job.ResultID != 0 && !changed {
+		return nil
+	}
+
+	call, err := p.AnalyzeCall(ctx, transcript, job.Language)
+	if err != nil {
+		return err
+	}
+
+	p.replaceResult(job, call.ID, p.service.DeleteCall)
+	return nil
+}
+
+// replaceResult links the job and the usage of its requests to its new result and deletes the partial one
+// saved by a previous run
+func (p *Pipeline) replaceResult(job *models.Job, id uint64, deleteResult func(id uint64) error) {
+	previous := job.ResultID
+	job.ResultID = id
+
+	if err := p.service.LinkJobUsage(job); err != nil {
+		log.Printf("[I] Failed to link usage of job %d: %v\n", job.ID, err)
+	}
+
+	if previous != 0 && previous != id {
+		if err := deleteResult(previous); err != nil {
+			log.Printf("[I] Failed to delete the previous result %d of job %d: %v\n", previous, job.ID, err)
+		}
+	}
+}
+
+// pendingChunksExist reports whether the files of all chunks still to transcribe are in place
+func pendingChunksExist(chunks models.JobChunks) bool {
+	for _, chunk := range chunks {
+		if chunk.Done {
+			continue
+		}
+		if _, err := os.Stat(chunk.Path); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// chunksDir returns the directory of the chunks of the job, no other job writes there
+func (p *Pipeline) chunksDir(id uint64) string {
+	return filepath.Join(p.cfg.ChunksDir, fmt.Sprintf("%s%d", jobChunksPrefix, id))
+}
+
+// removeChunks deletes the chunk directory of a job that does not resume with its chunks
+func (p *Pipeline) removeChunks(id uint64) {
+	if err := os.RemoveAll(p.chunksDir(id)); err != nil {
+		log.Printf("[I] Failed to remove chunks of job %d: %v\n", id, err)
+	}
+}
+
+// cleanChunks removes everything in the chunks directory but the chunk directories of the unfinished jobs
+// and of the running ones: chunks of finished jobs, of interrupted runs and of older versions splitting into one directory
+func (p *Pipeline) cleanChunks(unfinished []models.Job) {
+	entries, err := os.ReadDir(p.cfg.ChunksDir)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Printf("[I] Failed to read chunks directory: %v\n", err)
+		}
+		return
+	}
+
+	keep := make(map[string]bool, len(unfinished))
+	for _, job := range unfinished {
+		keep[filepath.Base(p.chunksDir(job.ID))] = true
+	}
+	p.jobsMx.Lock()
+	for id := range p.running {
+		keep[filepath.Base(p.chunksDir(id))] = true
+	}
+	p.jobsMx.Unlock()
+
+	var removed int
+	for _, entry := range entries {
+		if keep[entry.Name()] {
+			continue
+		}
+		if err = os.RemoveAll(filepath.Join(p.cfg.ChunksDir, entry.Name())); err != nil {
+			log.Printf("[I] Failed to remove orphaned chunks %s: %v\n", entry.Name(), err)
+			continue
+		}
+		removed++
+	}
+	if removed > 0 {
+		log.Printf("[I] Removed %d orphaned chunk entries\n", removed)
+	}
+}
@@ -0,0 +1,64 @@
+package pipeline
+
+import (
+	"context"
+	"sync"
+
+	"github.com/mrbelka12000/interview_parser/internal/client"
+	"github.com/mrbelka12000/interview_parser/internal/config"
+	"github.com/mrbelka12000/interview_parser/internal/parser"
+	"github.com/mrbelka12000/interview_parser/internal/service"
+)
+
+type (
+	// ProgressFunc receives progress updates while a file is being processed, jobID is 0 outside of jobs
+	ProgressFunc func(jobID uint64, percentage int, stage, details string)
+
+	// Pipeline runs media files through chunking, transcription and analysis.
+	// It is shared by the desktop app, the HTTP server and the CLI.
+	Pipeline struct {
+		cfg      *config.Config
+		parser   *parser.Parser
+		service  *service.Service
+		progress ProgressFunc
+
+		mx       sync.RWMutex
+		aiClient client.Provider
+
+		jobsMx  sync.Mutex
+		running map[uint64]*runningJob
+	}
+)
+
+func New(cfg *config.Config, p *parser.Parser, svc *service.Service, aiClient client.Provider, progress ProgressFunc) *Pipeline {
+	if progress == nil {
+		progress = func(uint64, int, string, string) {}
+	}
+
+	return &Pipeline{
+		cfg:      cfg,
+		parser:   p,
+		service:  svc,
+		progress: progress,
+		aiClient: aiClient,
+		running:  make(map[uint64]*runningJob),
+	}
+}
+
+// SetAIClient replaces the AI client, e.g. after the API key was changed
+func (p *Pipeline) SetAIClient(aiClient client.Provider) {
+	p.mx.Lock()
+	p.aiClient = aiClient
+	p.mx.Unlock()
+}
+
+// report sends a progress update of the job running with ctx
+func (p *Pipeline) report(ctx context.Context, percentage int, stage, details string) {
+	p.progress(client.JobFromContext(ctx), percentage, stage, details)
+}
+
+func (p *Pipeline) client() client.Provider {
+	p.mx.RLock()
+	defer p.mx.RUnlock()
+	return p.aiClient
+}
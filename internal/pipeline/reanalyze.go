@@ -0,0 +1,110 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/mrbelka12000/interview_parser/internal/client"
+	"github.com/mrbelka12000/interview_parser/internal/models"
+)
+
+var (
+	// ErrNoTranscript is returned when re-analyzing an interview saved before transcripts were kept
+	ErrNoTranscript = errors.New("the interview has no stored transcript")
+	// ErrUnknownPromptVersion is returned when re-analyzing with a prompt version that was never saved
+	ErrUnknownPromptVersion = errors.New("unknown prompt version")
+)
+
+// ReanalyzeOptions select how a stored interview transcript is analyzed again. Empty fields keep the configured
+// model, the latest prompt and the configured language.
+type ReanalyzeOptions struct {
+	Model string `json:"model,omitempty"`
+	// PromptVersion is a version of the analyze_interview template, 0 for the built-in one
+	PromptVersion *int            `json:"prompt_version,omitempty"`
+	Language      models.Language `json:"language,omitempty"`
+}
+
+// ReanalyzeInterview analyzes the stored transcript of the interview again and saves the result as its next
+// analysis version, keeping the previous ones for comparison. Unlike AnalyzeInterview it fails when any batch fails,
+// so a version never holds a part of the transcript.
+func (p *Pipeline) ReanalyzeInterview(ctx context.Context, id uint64, opts ReanalyzeOptions) (*models.AnalyzeInterviewWithQA, error) {
+	interview, err := p.service.GetInterview(id)
+	if err != nil {
+		return nil, err
+	}
+	if interview.Transcript == "" {
+		return nil, ErrNoTranscript
+	}
+
+	lang, err := models.ParseLanguage(string(opts.Language))
+	if err != nil {
+		return nil, err
+	}
+	lang = p.cfg.ResolveLanguage(lang, interview.Transcript)
+
+	// a re-analysis asked for explicitly gets a new answer, not the cached one of the same model and prompt
+	analysisOpts := client.AnalysisOptions{Model: opts.Model, SkipCache: true}
+	if opts.PromptVersion != nil {
+		tpl, err := p.promptVersion(client.PromptAnalyzeInterview, lang, *opts.PromptVersion)
+		if err != nil {
+			return nil, err
+		}
+		analysisOpts.Prompt = tpl
+	}
+	// the usage of the re-analysis adds to the cost of the interview
+	ctx = client.WithInterview(ctx, id)
+
+	batches := p.parser.BatchTranscript(interview.Transcript)
+	results := make([][]models.QuestionAnswer, len(batches))
+	versions := make([]string, len(batches))
+	errs := p.analyzeBatches(ctx, batches, lang, analysisOpts, results, versions, nil, nil)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if err := budgetError(errs); err != nil {
+		return nil, err
+	}
+	for i, err := range errs {
+		if err != nil {
+			return nil, fmt.Errorf("failed to analyze transcript batch %d: %w", i, err)
+		}
+	}
+
+	analysis := &models.InterviewAnalysis{
+		InterviewID:   id,
+		Model:         opts.Model,
+		PromptVersion: joinVersions(versions),
+		Language:      lang,
+	}
+	if analysis.Model == "" {
+		analysis.Model = p.cfg.GPTClassifyQuestionsModel
+	}
+	for _, batch := range results {
+		analysis.QA = append(analysis.QA, batch...)
+	}
+	p.parser.LinkTimestamps(analysis.QA, interview.Segments)
+
+	p.report(ctx, 97, "Saving analysis...", "Writing analysis version...")
+	if err := p.service.SaveInterviewAnalysis(analysis); err != nil {
+		return nil, fmt.Errorf("failed to save analysis: %w", err)
+	}
+
+	return p.service.GetInterview(id)
+}
+
+// promptVersion returns the version of the template of the name in the language, 0 is the built-in one
+func (p *Pipeline) promptVersion(name string, lang models.Language, version int) (*models.PromptTemplate, error) {
+	templates, err := client.PromptVersions(p.service, name, lang)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range templates {
+		if templates[i].Version == version {
+			return &templates[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s/%s has no version %d", ErrUnknownPromptVersion, name, lang, version)
+}
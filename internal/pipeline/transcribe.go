@@ -0,0 +1,153 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+
+	"github.com/mrbelka12000/interview_parser/internal/models"
+	"github.com/mrbelka12000/interview_parser/internal/parser"
+)
+
+// TranscribeFile splits the media file into chunks and transcribes them in parallel.
+// Segment times of the result are relative to the beginning of the file.
+// When only some chunks fail, the transcript of the others is returned with a *PartialError listing the failed ones.
+// lang is passed to the transcription as a hint of the spoken language, auto takes the configured one.
+func (p *Pipeline) TranscribeFile(ctx context.Context, filePath string, lang models.Language) (*models.Transcript, error) {
+	if err := os.MkdirAll(p.cfg.ChunksDir, 0o755); err != nil {
+		return nil, err
+	}
+	dir, err := os.MkdirTemp(p.cfg.ChunksDir, "run_*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create chunk directory: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	chunks, err := p.splitFile(ctx, filePath, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	collected := make([]models.Transcript, len(chunks))
+	errs := p.transcribeChunks(ctx, chunks, lang, collected, nil, nil)
+	if err = ctx.Err(); err != nil {
+		return nil, err
+	}
+	if err = budgetError(errs); err != nil {
+		return nil, err
+	}
+
+	var failures []models.JobFailure
+	for i, err := range errs {
+		if err != nil {
+			failures = append(failures, chunkFailure(i, chunks[i], err))
+		}
+	}
+	if len(failures) == len(chunks) {
+		return nil, fmt.Errorf("failed to transcribe all %d chunks: %s", len(chunks), failures[0].Error)
+	}
+
+	transcript := p.joinTranscript(filePath, chunks, collected)
+	if len(failures) > 0 {
+		return transcript, &PartialError{Chunks: failures}
+	}
+
+	return transcript, nil
+}
+
+// splitFile splits the media file into the chunks sent for transcription, placed in dir
+func (p *Pipeline) splitFile(ctx context.Context, filePath, dir string) ([]parser.Chunk, error) {
+	p.report(ctx, 15, "Splitting into chunks...", "Dividing file into manageable segments...")
+	chunks, err := p.parser.SplitIntoChunks(ctx, p.cfg, filePath, dir)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, fmt.Errorf("failed to split into chunks: %w", err)
+	}
+
+	if len(chunks) == 0 {
+		return nil, fmt.Errorf("no chunks to process")
+	}
+
+	return chunks, nil
+}
+
+// transcribeChunks transcribes the chunks of speech in lang in parallel into collected, skipping the ones marked done,
+// and returns the errors of the chunks that failed after all retries.
+// onDone is called for every attempted chunk while no other chunk is being collected.
+func (p *Pipeline) transcribeChunks(ctx context.Context, chunks []parser.Chunk, lang models.Language, collected []models.Transcript, done []bool, onDone func(ind int, err error)) []error {
+	// Step 2: Transcribe chunks using the provided parser logic
+	p.report(ctx, 25, "Transcribing audio...", "Converting speech to text using AI...")
+
+	var (
+		wg        sync.WaitGroup
+		mx        sync.Mutex
+		aiClient  = p.client()
+		workers   = make(chan struct{}, p.cfg.ParallelWorkers)
+		completed = 0
+		errs      = make([]error, len(chunks))
+	)
+
+	for i, chunk := range chunks {
+		if i < len(done) && done[i] {
+			completed++
+			continue
+		}
+
+		wg.Add(1)
+		workers <- struct{}{}
+		go func(ind int, chunkVar parser.Chunk) {
+			defer func() {
+				<-workers
+				wg.Done()
+			}()
+
+			chunkTranscript, err := aiClient.Transcribe(ctx, chunkVar.Path, chunkVar.Duration, lang)
+
+			mx.Lock()
+			defer mx.Unlock()
+
+			if onDone != nil {
+				defer onDone(ind, err)
+			}
+			if err != nil {
+				log.Printf("Error transcribing chunk %d: %v", ind, err)
+				errs[ind] = err
+				return
+			}
+
+			collected[ind] = chunkTranscript
+			completed++
+			progress := 25 + int(float64(completed)/float64(len(chunks))*35) // 25% to 60%
+			p.report(ctx, progress, "Processing chunks...", fmt.Sprintf("Processed %d/%d audio segments...", completed, len(chunks)))
+		}(i, chunk)
+	}
+
+	wg.Wait()
+
+	return errs
+}
+
+// joinTranscript stitches the chunk transcripts and labels the segments with the speakers known from the recording
+func (p *Pipeline) joinTranscript(filePath string, chunks []parser.Chunk, collected []models.Transcript) *models.Transcript {
+	// overlapping chunks repeat the words at their edges, the stitching keeps them once
+	transcript := p.parser.StitchTranscripts(chunks, collected)
+
+	// recordings made in the app know who spoke when from the separate mic and system streams
+	turns, err := p.parser.LoadSpeakerTurns(filePath)
+	if err != nil {
+		log.Printf("Error loading speaker turns: %v", err)
+	}
+	if len(turns) == 0 {
+		turns, err = p.parser.SpeakerTurnsFromTracks(filePath)
+		if err != nil {
+			log.Printf("Error reading speaker turns from tracks: %v", err)
+		}
+	}
+	transcript.Segments = p.parser.ApplySpeakerTurns(transcript.Segments, turns)
+
+	return transcript
+}
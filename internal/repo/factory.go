@@ -7,7 +7,7 @@ import (
 )
 
 // NewRepositories creates repository instances based on database configuration
-func NewRepositories(cfg *config.Config) (ApiKeyRepository, InterviewRepository, CallRepository) {
+func NewRepositories(cfg *config.Config) (ApiKeyRepository, InterviewRepository, CallRepository, JobRepository, UsageRepository, PromptRepository) {
 	switch {
 	case cfg.DBConfig.PGURL != "":
 		if err := postgres.InitDB(cfg.DBConfig.PGURL); err != nil {
@@ -23,19 +23,25 @@ func NewRepositories(cfg *config.Config) (ApiKeyRepository, InterviewRepository,
 }
 
 // newPostgresRepositories creates PostgreSQL repository instances
-func newPostgresRepositories() (ApiKeyRepository, InterviewRepository, CallRepository) {
+func newPostgresRepositories() (ApiKeyRepository, InterviewRepository, CallRepository, JobRepository, UsageRepository, PromptRepository) {
 	apiKeyRepo := postgres.NewApiKeyRepo()
 	interviewRepo := postgres.NewInterviewRepo()
 	callRepo := postgres.NewCallRepo()
+	jobRepo := postgres.NewJobRepo()
+	usageRepo := postgres.NewUsageRepo()
+	promptRepo := postgres.NewPromptRepo()
 
-	return apiKeyRepo, interviewRepo, callRepo
+	return apiKeyRepo, interviewRepo, callRepo, jobRepo, usageRepo, promptRepo
 }
 
 // newSQLiteRepositories creates SQLite repository instances
-func newSQLiteRepositories() (ApiKeyRepository, InterviewRepository, CallRepository) {
+func newSQLiteRepositories() (ApiKeyRepository, InterviewRepository, CallRepository, JobRepository, UsageRepository, PromptRepository) {
 	apiKeyRepo := sqlite.NewApiKeyRepo()
 	interviewRepo := sqlite.NewInterviewRepo()
 	callRepo := sqlite.NewCallRepo()
+	jobRepo := sqlite.NewJobRepo()
+	usageRepo := sqlite.NewUsageRepo()
+	promptRepo := sqlite.NewPromptRepo()
 
-	return apiKeyRepo, interviewRepo, callRepo
+	return apiKeyRepo, interviewRepo, callRepo, jobRepo, usageRepo, promptRepo
 }
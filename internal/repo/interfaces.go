@@ -19,7 +19,10 @@ type InterviewRepository interface {
 	Get(id uint64) (*models.AnalyzeInterview, []models.QuestionAnswer, error)
 	GetAll(filters *models.GetInterviewsFilters) ([]models.AnalyzeInterview, [][]models.QuestionAnswer, error)
 	Update(interview *models.AnalyzeInterview, qaList []models.QuestionAnswer) error
+	RenameSpeaker(id uint64, from, to string) error
 	Delete(id uint64) error
+	SaveAnalysis(analysis *models.InterviewAnalysis) error
+	GetAnalyses(interviewID uint64) ([]models.InterviewAnalysis, error)
 }
 
 // CallRepository defines interface for call operations
@@ -31,3 +34,27 @@ type CallRepository interface {
 	Delete(id uint64) error
 	GetByDateRange(dateFrom, dateTo time.Time) ([]models.Call, error)
 }
+
+// JobRepository defines interface for processing job operations
+type JobRepository interface {
+	Create(job *models.Job) (uint64, error)
+	Get(id uint64) (*models.Job, error)
+	GetAll(limit int) ([]models.Job, error)
+	GetByStatus(statuses ...models.JobStatus) ([]models.Job, error)
+	Update(job *models.Job) error
+}
+
+// UsageRepository defines interface for API usage operations
+type UsageRepository interface {
+	Create(usage *models.Usage) error
+	LinkJob(jobID, interviewID, callID uint64) error
+	GetAll(filters *models.GetUsageFilters) ([]models.Usage, error)
+	GetCost(filters *models.GetUsageFilters) (float64, error)
+}
+
+// PromptRepository defines interface for prompt template operations
+type PromptRepository interface {
+	Create(prompt *models.PromptTemplate) error
+	GetLatest(name string, lang models.Language) (*models.PromptTemplate, error)
+	GetVersions(name string, lang models.Language) ([]models.PromptTemplate, error)
+}
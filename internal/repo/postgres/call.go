@@ -69,6 +69,7 @@ func (r *CallRepo) Update(call *models.Call) error {
 	result := GetDB().Model(call).Updates(map[string]interface{}{
 		"transcript": call.Transcript,
 		"analysis":   call.Analysis,
+		"segments":   call.Segments,
 		"updated_at": now,
 	})
 
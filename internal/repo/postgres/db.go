@@ -33,6 +33,10 @@ func InitDB(pgURL string) error {
 		&models.AnalyzeInterview{},
 		&models.QuestionAnswer{},
 		&models.Call{},
+		&models.Job{},
+		&models.Usage{},
+		&models.PromptTemplate{},
+		&models.InterviewAnalysis{},
 	)
 	if err != nil {
 		return fmt.Errorf("failed to migrate database: %w", err)
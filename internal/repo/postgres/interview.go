@@ -3,6 +3,7 @@ package postgres
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"gorm.io/gorm"
 
@@ -20,8 +21,11 @@ func (r *InterviewRepo) Save(interview *models.AnalyzeInterviewWithQA) error {
 	return GetDB().Transaction(func(tx *gorm.DB) error {
 		// Create interview
 		interviewModel := &models.AnalyzeInterview{
-			CreatedAt: interview.CreatedAt,
-			UpdatedAt: interview.UpdatedAt,
+			Transcript:    interview.Transcript,
+			Segments:      interview.Segments,
+			PromptVersion: interview.PromptVersion,
+			CreatedAt:     interview.CreatedAt,
+			UpdatedAt:     interview.UpdatedAt,
 		}
 
 		if err := tx.Create(interviewModel).Error; err != nil {
@@ -61,9 +65,9 @@ func (r *InterviewRepo) Get(id uint64) (*models.AnalyzeInterview, []models.Quest
 	return &interview, qaList, nil
 }
 
-// GetAll retrieves all interviews with their question answers
+// GetAll retrieves all interviews with their question answers, without their transcripts
 func (r *InterviewRepo) GetAll(filters *models.GetInterviewsFilters) ([]models.AnalyzeInterview, [][]models.QuestionAnswer, error) {
-	query := GetDB().Model(&models.AnalyzeInterview{})
+	query := GetDB().Model(&models.AnalyzeInterview{}).Select("id", "segments", "prompt_version", "created_at", "updated_at")
 
 	if filters != nil {
 		if filters.DateFrom != nil {
@@ -117,8 +121,44 @@ func (r *InterviewRepo) Update(interview *models.AnalyzeInterview, qaList []mode
 	})
 }
 
-// Delete deletes an interview and its question answers
+// RenameSpeaker renames the speaker in the transcript segments and question answers of an interview
+func (r *InterviewRepo) RenameSpeaker(id uint64, from, to string) error {
+	return GetDB().Transaction(func(tx *gorm.DB) error {
+		var interview models.AnalyzeInterview
+		if err := tx.First(&interview, id).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return fmt.Errorf("no interview found with id: %d", id)
+			}
+			return fmt.Errorf("failed to retrieve interview: %w", err)
+		}
+
+		interview.Segments.RenameSpeaker(from, to)
+		if err := tx.Model(&interview).Update("segments", interview.Segments).Error; err != nil {
+			return fmt.Errorf("failed to update interview: %w", err)
+		}
+
+		if err := tx.Model(&models.QuestionAnswer{}).
+			Where("interview_id = ? AND questioner = ?", id, from).
+			Update("questioner", to).Error; err != nil {
+			return fmt.Errorf("failed to rename questioner: %w", err)
+		}
+
+		if err := tx.Model(&models.QuestionAnswer{}).
+			Where("interview_id = ? AND answerer = ?", id, from).
+			Update("answerer", to).Error; err != nil {
+			return fmt.Errorf("failed to rename answerer: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// Delete deletes an interview, its question answers and analysis versions
 func (r *InterviewRepo) Delete(id uint64) error {
+	if err := GetDB().Where("interview_id = ?", id).Delete(&models.InterviewAnalysis{}).Error; err != nil {
+		return fmt.Errorf("failed to delete interview analyses: %w", err)
+	}
+
 	result := GetDB().Delete(&models.AnalyzeInterview{}, id)
 	if result.Error != nil {
 		return fmt.Errorf("failed to delete interview: %w", result.Error)
@@ -131,6 +171,79 @@ func (r *InterviewRepo) Delete(id uint64) error {
 	return nil
 }
 
+// SaveAnalysis saves the analysis as the next version of the interview and replaces the question answers
+// of the interview with it. The first saved analysis keeps the one the interview was saved with as version 1.
+func (r *InterviewRepo) SaveAnalysis(analysis *models.InterviewAnalysis) error {
+	interview, qaList, err := r.Get(analysis.InterviewID)
+	if err != nil {
+		return err
+	}
+
+	return GetDB().Transaction(func(tx *gorm.DB) error {
+		var latest int
+		err := tx.Model(&models.InterviewAnalysis{}).
+			Where("interview_id = ?", interview.ID).
+			Select("COALESCE(MAX(version), 0)").
+			Scan(&latest).Error
+		if err != nil {
+			return fmt.Errorf("failed to get interview analysis version: %w", err)
+		}
+
+		if latest == 0 {
+			first := &models.InterviewAnalysis{
+				InterviewID:   interview.ID,
+				Version:       1,
+				PromptVersion: interview.PromptVersion,
+				QA:            qaList,
+				CreatedAt:     interview.CreatedAt,
+			}
+			if err := tx.Create(first).Error; err != nil {
+				return fmt.Errorf("failed to keep the first interview analysis: %w", err)
+			}
+			latest = 1
+		}
+
+		err = tx.Model(interview).Updates(map[string]any{
+			"prompt_version": analysis.PromptVersion,
+			"updated_at":     time.Now(),
+		}).Error
+		if err != nil {
+			return fmt.Errorf("failed to update interview: %w", err)
+		}
+
+		if err := tx.Where("interview_id = ?", interview.ID).Delete(&models.QuestionAnswer{}).Error; err != nil {
+			return fmt.Errorf("failed to delete existing question answers: %w", err)
+		}
+		for i := range analysis.QA {
+			qa := &analysis.QA[i]
+			qa.ID = 0
+			qa.InterviewID = interview.ID
+			if err := tx.Create(qa).Error; err != nil {
+				return fmt.Errorf("failed to create question answer: %w", err)
+			}
+		}
+
+		analysis.ID = 0
+		analysis.InterviewID = interview.ID
+		analysis.Version = latest + 1
+		if err := tx.Create(analysis).Error; err != nil {
+			return fmt.Errorf("failed to create interview analysis: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// GetAnalyses retrieves the saved analysis versions of an interview, oldest first
+func (r *InterviewRepo) GetAnalyses(interviewID uint64) ([]models.InterviewAnalysis, error) {
+	var analyses []models.InterviewAnalysis
+	if err := GetDB().Where("interview_id = ?", interviewID).Order("version").Find(&analyses).Error; err != nil {
+		return nil, fmt.Errorf("failed to query interview analyses: %w", err)
+	}
+
+	return analyses, nil
+}
+
 // Helper method to get question answers by interview ID
 func (r *InterviewRepo) getQuestionAnswersByInterviewID(interviewID uint64) ([]models.QuestionAnswer, error) {
 	var qaList []models.QuestionAnswer
@@ -0,0 +1,103 @@
+package postgres
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/mrbelka12000/interview_parser/internal/models"
+)
+
+type JobRepo struct{}
+
+func NewJobRepo() *JobRepo {
+	return &JobRepo{}
+}
+
+// Create creates a new job record
+func (r *JobRepo) Create(job *models.Job) (uint64, error) {
+	now := time.Now()
+	job.CreatedAt = now
+	job.UpdatedAt = now
+
+	if err := GetDB().Create(job).Error; err != nil {
+		return 0, fmt.Errorf("failed to create job: %w", err)
+	}
+
+	return job.ID, nil
+}
+
+// Get retrieves a job by ID
+func (r *JobRepo) Get(id uint64) (*models.Job, error) {
+	var job models.Job
+	if err := GetDB().First(&job, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("no job found with id: %d", id)
+		}
+		return nil, fmt.Errorf("failed to retrieve job: %w", err)
+	}
+
+	return &job, nil
+}
+
+// GetAll retrieves the latest jobs
+func (r *JobRepo) GetAll(limit int) ([]models.Job, error) {
+	query := GetDB().Order("created_at DESC, id DESC")
+
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	var jobs []models.Job
+	if err := query.Find(&jobs).Error; err != nil {
+		return nil, fmt.Errorf("failed to query jobs: %w", err)
+	}
+
+	return jobs, nil
+}
+
+// GetByStatus retrieves the jobs in any of the statuses, oldest first
+func (r *JobRepo) GetByStatus(statuses ...models.JobStatus) ([]models.Job, error) {
+	if len(statuses) == 0 {
+		return nil, nil
+	}
+
+	var jobs []models.Job
+	if err := GetDB().Where("status IN ?", statuses).
+		Order("created_at, id").
+		Find(&jobs).Error; err != nil {
+		return nil, fmt.Errorf("failed to query jobs by status: %w", err)
+	}
+
+	return jobs, nil
+}
+
+// Update updates the state of an existing job
+func (r *JobRepo) Update(job *models.Job) error {
+	now := time.Now()
+	job.UpdatedAt = now
+
+	result := GetDB().Model(job).Updates(map[string]interface{}{
+		"status":          job.Status,
+		"transcript_path": job.TranscriptPath,
+		"language":        job.Language,
+		"chunks":          job.Chunks,
+		"transcript":      job.Transcript,
+		"batches":         job.Batches,
+		"result_id":       job.ResultID,
+		"error":           job.Error,
+		"updated_at":      now,
+	})
+
+	if result.Error != nil {
+		return fmt.Errorf("failed to update job: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("no job found with id: %d", job.ID)
+	}
+
+	return nil
+}
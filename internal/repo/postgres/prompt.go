@@ -0,0 +1,64 @@
+package postgres
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/mrbelka12000/interview_parser/internal/models"
+)
+
+type PromptRepo struct{}
+
+func NewPromptRepo() *PromptRepo {
+	return &PromptRepo{}
+}
+
+// Create saves the prompt template as the next version of its name and language
+func (r *PromptRepo) Create(prompt *models.PromptTemplate) error {
+	return GetDB().Transaction(func(tx *gorm.DB) error {
+		var latest int
+		err := tx.Model(&models.PromptTemplate{}).
+			Where("name = ? AND language = ?", prompt.Name, prompt.Language).
+			Select("COALESCE(MAX(version), 0)").
+			Scan(&latest).Error
+		if err != nil {
+			return fmt.Errorf("failed to get prompt template version: %w", err)
+		}
+
+		prompt.ID = 0
+		prompt.Version = latest + 1
+		prompt.CreatedAt = time.Now()
+		if err = tx.Create(prompt).Error; err != nil {
+			return fmt.Errorf("failed to create prompt template: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// GetLatest retrieves the latest version of the prompt template, nil when it has none
+func (r *PromptRepo) GetLatest(name string, lang models.Language) (*models.PromptTemplate, error) {
+	var prompt models.PromptTemplate
+	err := GetDB().Where("name = ? AND language = ?", name, lang).Order("version DESC").First(&prompt).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to retrieve prompt template: %w", err)
+	}
+
+	return &prompt, nil
+}
+
+// GetVersions retrieves all versions of the prompt template, oldest first
+func (r *PromptRepo) GetVersions(name string, lang models.Language) ([]models.PromptTemplate, error) {
+	var prompts []models.PromptTemplate
+	if err := GetDB().Where("name = ? AND language = ?", name, lang).Order("version").Find(&prompts).Error; err != nil {
+		return nil, fmt.Errorf("failed to query prompt templates: %w", err)
+	}
+
+	return prompts, nil
+}
@@ -0,0 +1,86 @@
+package postgres
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/mrbelka12000/interview_parser/internal/models"
+)
+
+type UsageRepo struct{}
+
+func NewUsageRepo() *UsageRepo {
+	return &UsageRepo{}
+}
+
+// Create records the usage of a request
+func (r *UsageRepo) Create(usage *models.Usage) error {
+	usage.CreatedAt = time.Now()
+
+	if err := GetDB().Create(usage).Error; err != nil {
+		return fmt.Errorf("failed to create usage: %w", err)
+	}
+
+	return nil
+}
+
+// LinkJob links the usage of the job requests to the interview or call the job saved
+func (r *UsageRepo) LinkJob(jobID, interviewID, callID uint64) error {
+	err := GetDB().Model(&models.Usage{}).
+		Where("job_id = ?", jobID).
+		Updates(map[string]interface{}{
+			"interview_id": interviewID,
+			"call_id":      callID,
+		}).Error
+	if err != nil {
+		return fmt.Errorf("failed to link usage of job %d: %w", jobID, err)
+	}
+
+	return nil
+}
+
+// GetAll retrieves the usage of the requests matching the filters, oldest first
+func (r *UsageRepo) GetAll(filters *models.GetUsageFilters) ([]models.Usage, error) {
+	var usages []models.Usage
+	if err := usageQuery(filters).Order("created_at, id").Find(&usages).Error; err != nil {
+		return nil, fmt.Errorf("failed to query usage: %w", err)
+	}
+
+	return usages, nil
+}
+
+// GetCost sums the cost of the requests matching the filters
+func (r *UsageRepo) GetCost(filters *models.GetUsageFilters) (float64, error) {
+	var cost float64
+	if err := usageQuery(filters).Select("COALESCE(SUM(cost), 0)").Scan(&cost).Error; err != nil {
+		return 0, fmt.Errorf("failed to sum usage cost: %w", err)
+	}
+
+	return cost, nil
+}
+
+func usageQuery(filters *models.GetUsageFilters) *gorm.DB {
+	query := GetDB().Model(&models.Usage{})
+
+	if filters != nil {
+		if filters.DateFrom != nil {
+			query = query.Where("created_at >= ?", *filters.DateFrom)
+		}
+		if filters.DateTo != nil {
+			query = query.Where("created_at <= ?", *filters.DateTo)
+		}
+		if filters.JobID != 0 {
+			query = query.Where("job_id = ?", filters.JobID)
+		}
+		if filters.InterviewID != 0 {
+			query = query.Where("interview_id = ?", filters.InterviewID)
+		}
+		if filters.CallID != 0 {
+			query = query.Where("call_id = ?", filters.CallID)
+		}
+	}
+
+	return query
+}
@@ -20,8 +20,8 @@ func NewCallRepo() *CallRepo {
 func (r *CallRepo) Create(call *models.Call) (uint64, error) {
 	now := time.Now()
 	query := `
-	INSERT INTO calls (transcript, analysis, created_at, updated_at) 
-	VALUES (?, ?, ?, ?)
+	INSERT INTO calls (transcript, analysis, segments, prompt_version, created_at, updated_at) 
+	VALUES (?, ?, ?, ?, ?, ?)
 	`
 
 	var (
@@ -34,7 +34,7 @@ func (r *CallRepo) Create(call *models.Call) (uint64, error) {
 		analysisJSON = []byte("null")
 	}
 
-	result, err := db.Exec(query, call.Transcript, analysisJSON, now, now)
+	result, err := db.Exec(query, call.Transcript, analysisJSON, call.Segments, call.PromptVersion, now, now)
 	if err != nil {
 		return 0, fmt.Errorf("failed to insert call: %w", err)
 	}
@@ -54,7 +54,7 @@ func (r *CallRepo) Create(call *models.Call) (uint64, error) {
 // Get retrieves a call by ID
 func (r *CallRepo) Get(id uint64) (*models.Call, error) {
 	query := `
-	SELECT id, transcript, analysis, created_at, updated_at 
+	SELECT id, transcript, analysis, segments, prompt_version, created_at, updated_at 
 	FROM calls 
 	WHERE id = ?
 	`
@@ -62,7 +62,7 @@ func (r *CallRepo) Get(id uint64) (*models.Call, error) {
 	var call models.Call
 	var analysisJSON []byte
 
-	err := db.QueryRow(query, id).Scan(&call.ID, &call.Transcript, &analysisJSON, &call.CreatedAt, &call.UpdatedAt)
+	err := db.QueryRow(query, id).Scan(&call.ID, &call.Transcript, &analysisJSON, &call.Segments, &call.PromptVersion, &call.CreatedAt, &call.UpdatedAt)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, fmt.Errorf("no call found with id: %d", id)
@@ -70,13 +70,17 @@ func (r *CallRepo) Get(id uint64) (*models.Call, error) {
 		return nil, fmt.Errorf("failed to retrieve call: %w", err)
 	}
 
+	if analysisJSON != nil {
+		call.Analysis = json.RawMessage(analysisJSON)
+	}
+
 	return &call, nil
 }
 
 // GetAll retrieves all calls with optional pagination
 func (r *CallRepo) GetAll(limit, offset int) ([]models.Call, error) {
 	query := `
-	SELECT id, transcript, analysis, created_at, updated_at 
+	SELECT id, transcript, analysis, segments, prompt_version, created_at, updated_at 
 	FROM calls 
 	ORDER BY created_at DESC
 	`
@@ -102,7 +106,7 @@ func (r *CallRepo) GetAll(limit, offset int) ([]models.Call, error) {
 		var call models.Call
 		var analysisJSON []byte
 
-		err := rows.Scan(&call.ID, &call.Transcript, &analysisJSON, &call.CreatedAt, &call.UpdatedAt)
+		err := rows.Scan(&call.ID, &call.Transcript, &analysisJSON, &call.Segments, &call.PromptVersion, &call.CreatedAt, &call.UpdatedAt)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan call row: %w", err)
 		}
@@ -127,7 +131,7 @@ func (r *CallRepo) Update(call *models.Call) error {
 	now := time.Now()
 	query := `
 	UPDATE calls 
-	SET transcript = ?, analysis = ?, updated_at = ? 
+	SET transcript = ?, analysis = ?, segments = ?, updated_at = ? 
 	WHERE id = ?
 	`
 
@@ -138,7 +142,7 @@ func (r *CallRepo) Update(call *models.Call) error {
 		analysisJSON = []byte("null")
 	}
 
-	result, err := db.Exec(query, call.Transcript, analysisJSON, now, call.ID)
+	result, err := db.Exec(query, call.Transcript, analysisJSON, call.Segments, now, call.ID)
 	if err != nil {
 		return fmt.Errorf("failed to update call: %w", err)
 	}
@@ -179,7 +183,7 @@ func (r *CallRepo) Delete(id uint64) error {
 // GetByDateRange retrieves calls within a date range
 func (r *CallRepo) GetByDateRange(dateFrom, dateTo time.Time) ([]models.Call, error) {
 	query := `
-	SELECT id, transcript, analysis, created_at, updated_at 
+	SELECT id, transcript, analysis, segments, prompt_version, created_at, updated_at 
 	FROM calls 
 	WHERE created_at >= ? AND created_at <= ?
 	ORDER BY created_at DESC
@@ -196,7 +200,7 @@ func (r *CallRepo) GetByDateRange(dateFrom, dateTo time.Time) ([]models.Call, er
 		var call models.Call
 		var analysisJSON []byte
 
-		err := rows.Scan(&call.ID, &call.Transcript, &analysisJSON, &call.CreatedAt, &call.UpdatedAt)
+		err := rows.Scan(&call.ID, &call.Transcript, &analysisJSON, &call.Segments, &call.PromptVersion, &call.CreatedAt, &call.UpdatedAt)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan call row: %w", err)
 		}
@@ -89,5 +89,149 @@ CREATE TABLE IF NOT EXISTS api_keys (
 		return fmt.Errorf("create calls table: %w", err)
 	}
 
+	ddl = `
+	CREATE TABLE IF NOT EXISTS jobs (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		type TEXT NOT NULL,
+		status TEXT NOT NULL,
+		file_path TEXT NOT NULL,
+		transcript_path TEXT NOT NULL DEFAULT '',
+		chunks TEXT,
+		transcript TEXT,
+		batches TEXT,
+		result_id INTEGER NOT NULL DEFAULT 0,
+		error TEXT NOT NULL DEFAULT '',
+		created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_jobs_status ON jobs(status);
+	`
+
+	_, err = db.Exec(ddl)
+	if err != nil {
+		return fmt.Errorf("create jobs table: %w", err)
+	}
+
+	ddl = `
+	CREATE TABLE IF NOT EXISTS usages (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		operation TEXT NOT NULL,
+		model TEXT NOT NULL,
+		audio_seconds REAL NOT NULL DEFAULT 0,
+		prompt_tokens INTEGER NOT NULL DEFAULT 0,
+		completion_tokens INTEGER NOT NULL DEFAULT 0,
+		cost REAL NOT NULL DEFAULT 0,
+		job_id INTEGER NOT NULL DEFAULT 0,
+		interview_id INTEGER NOT NULL DEFAULT 0,
+		call_id INTEGER NOT NULL DEFAULT 0,
+		created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_usages_created_at ON usages(created_at);
+	CREATE INDEX IF NOT EXISTS idx_usages_job_id ON usages(job_id);
+	CREATE INDEX IF NOT EXISTS idx_usages_interview_id ON usages(interview_id);
+	CREATE INDEX IF NOT EXISTS idx_usages_call_id ON usages(call_id);
+	`
+
+	_, err = db.Exec(ddl)
+	if err != nil {
+		return fmt.Errorf("create usages table: %w", err)
+	}
+
+	ddl = `
+	CREATE TABLE IF NOT EXISTS prompt_templates (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		name TEXT NOT NULL,
+		language TEXT NOT NULL,
+		version INTEGER NOT NULL,
+		label TEXT NOT NULL DEFAULT '',
+		body TEXT NOT NULL,
+		created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+	);
+
+	CREATE UNIQUE INDEX IF NOT EXISTS idx_prompt_templates_version ON prompt_templates(name, language, version);
+	`
+
+	_, err = db.Exec(ddl)
+	if err != nil {
+		return fmt.Errorf("create prompt templates table: %w", err)
+	}
+
+	ddl = `
+	CREATE TABLE IF NOT EXISTS interview_analyses (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		interview_id INTEGER NOT NULL,
+		version INTEGER NOT NULL,
+		model TEXT NOT NULL DEFAULT '',
+		prompt_version TEXT NOT NULL DEFAULT '',
+		language TEXT NOT NULL DEFAULT '',
+		qa TEXT,
+		created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		FOREIGN KEY (interview_id) REFERENCES interviews(id) ON DELETE CASCADE
+	);
+
+	CREATE UNIQUE INDEX IF NOT EXISTS idx_interview_analyses_version ON interview_analyses(interview_id, version);
+	`
+
+	_, err = db.Exec(ddl)
+	if err != nil {
+		return fmt.Errorf("create interview analyses table: %w", err)
+	}
+
+	// columns added after the first release, existing databases get them here
+	columns := []struct {
+		table, column, definition string
+	}{
+		{"interviews", "segments", "TEXT"},
+		{"question_answers", "start_time", "REAL"},
+		{"question_answers", "end_time", "REAL"},
+		{"question_answers", "questioner", "TEXT NOT NULL DEFAULT ''"},
+		{"question_answers", "answerer", "TEXT NOT NULL DEFAULT ''"},
+		{"calls", "segments", "TEXT"},
+		{"jobs", "language", "TEXT NOT NULL DEFAULT 'auto'"},
+		{"interviews", "prompt_version", "TEXT NOT NULL DEFAULT ''"},
+		{"calls", "prompt_version", "TEXT NOT NULL DEFAULT ''"},
+		{"interviews", "transcript", "TEXT NOT NULL DEFAULT ''"},
+	}
+	for _, c := range columns {
+		if err = addColumnIfNotExists(c.table, c.column, c.definition); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func addColumnIfNotExists(table, column, definition string) error {
+	rows, err := db.Query(fmt.Sprintf("PRAGMA table_info(%s)", table))
+	if err != nil {
+		return fmt.Errorf("read %s columns: %w", table, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			cid        int
+			name, typ  string
+			notNull    bool
+			dflt       sql.NullString
+			primaryKey int
+		)
+		if err = rows.Scan(&cid, &name, &typ, &notNull, &dflt, &primaryKey); err != nil {
+			return fmt.Errorf("scan %s columns: %w", table, err)
+		}
+		if name == column {
+			return nil
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("iterate %s columns: %w", table, err)
+	}
+
+	if _, err = db.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table, column, definition)); err != nil {
+		return fmt.Errorf("add %s.%s column: %w", table, column, err)
+	}
+
 	return nil
 }
@@ -26,10 +26,10 @@ func (r *InterviewRepo) Save(interview *models.AnalyzeInterviewWithQA) error {
 	// Insert interview
 	now := time.Now()
 	query := `
-	INSERT INTO interviews (created_at, updated_at) 
-	VALUES (?, ?)
+	INSERT INTO interviews (transcript, segments, prompt_version, created_at, updated_at) 
+	VALUES (?, ?, ?, ?, ?)
 	`
-	result, err := tx.Exec(query, now, now)
+	result, err := tx.Exec(query, interview.Transcript, interview.Segments, interview.PromptVersion, now, now)
 	if err != nil {
 		return fmt.Errorf("failed to insert interview: %w", err)
 	}
@@ -47,10 +47,10 @@ func (r *InterviewRepo) Save(interview *models.AnalyzeInterviewWithQA) error {
 		qa.UpdatedAt = now
 
 		qaQuery := `
-		INSERT INTO question_answers (interview_id, question, full_answer, accuracy, reason_unanswered, created_at, updated_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?)
+		INSERT INTO question_answers (interview_id, question, full_answer, accuracy, reason_unanswered, questioner, answerer, start_time, end_time, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 		`
-		_, err = tx.Exec(qaQuery, qa.InterviewID, qa.Question, qa.FullAnswer, qa.Accuracy, qa.ReasonUnanswered, qa.CreatedAt, qa.UpdatedAt)
+		_, err = tx.Exec(qaQuery, qa.InterviewID, qa.Question, qa.FullAnswer, qa.Accuracy, qa.ReasonUnanswered, qa.Questioner, qa.Answerer, qa.StartTime, qa.EndTime, qa.CreatedAt, qa.UpdatedAt)
 		if err != nil {
 			return fmt.Errorf("failed to insert question answer: %w", err)
 		}
@@ -60,6 +60,10 @@ func (r *InterviewRepo) Save(interview *models.AnalyzeInterviewWithQA) error {
 		return fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
+	interview.ID = uint64(interviewID)
+	interview.CreatedAt = now
+	interview.UpdatedAt = now
+
 	return nil
 }
 
@@ -67,12 +71,12 @@ func (r *InterviewRepo) Save(interview *models.AnalyzeInterviewWithQA) error {
 func (r *InterviewRepo) Get(id uint64) (*models.AnalyzeInterview, []models.QuestionAnswer, error) {
 	// Get interview
 	query := `
-	SELECT id, created_at, updated_at 
+	SELECT id, transcript, segments, prompt_version, created_at, updated_at 
 	FROM interviews 
 	WHERE id = ?
 	`
 	var interview models.AnalyzeInterview
-	err := db.QueryRow(query, id).Scan(&interview.ID, &interview.CreatedAt, &interview.UpdatedAt)
+	err := db.QueryRow(query, id).Scan(&interview.ID, &interview.Transcript, &interview.Segments, &interview.PromptVersion, &interview.CreatedAt, &interview.UpdatedAt)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, nil, fmt.Errorf("no interview found with id: %d", id)
@@ -82,7 +86,7 @@ func (r *InterviewRepo) Get(id uint64) (*models.AnalyzeInterview, []models.Quest
 
 	// Get question answers
 	qaQuery := `
-	SELECT id, interview_id, question, full_answer, accuracy, reason_unanswered, created_at, updated_at
+	SELECT id, interview_id, question, full_answer, accuracy, reason_unanswered, questioner, answerer, start_time, end_time, created_at, updated_at
 	FROM question_answers 
 	WHERE interview_id = ?
 	ORDER BY id
@@ -96,7 +100,7 @@ func (r *InterviewRepo) Get(id uint64) (*models.AnalyzeInterview, []models.Quest
 	var qaList []models.QuestionAnswer
 	for rows.Next() {
 		var qa models.QuestionAnswer
-		err := rows.Scan(&qa.ID, &qa.InterviewID, &qa.Question, &qa.FullAnswer, &qa.Accuracy, &qa.ReasonUnanswered, &qa.CreatedAt, &qa.UpdatedAt)
+		err := rows.Scan(&qa.ID, &qa.InterviewID, &qa.Question, &qa.FullAnswer, &qa.Accuracy, &qa.ReasonUnanswered, &qa.Questioner, &qa.Answerer, &qa.StartTime, &qa.EndTime, &qa.CreatedAt, &qa.UpdatedAt)
 		if err != nil {
 			return nil, nil, fmt.Errorf("failed to scan question answer row: %w", err)
 		}
@@ -110,11 +114,11 @@ func (r *InterviewRepo) Get(id uint64) (*models.AnalyzeInterview, []models.Quest
 	return &interview, qaList, nil
 }
 
-// GetAll retrieves all interviews with their question answers
+// GetAll retrieves all interviews with their question answers, without their transcripts
 func (r *InterviewRepo) GetAll(filters *models.GetInterviewsFilters) ([]models.AnalyzeInterview, [][]models.QuestionAnswer, error) {
 	// Build query with filters
 	query := `
-	SELECT id, created_at, updated_at 
+	SELECT id, segments, prompt_version, created_at, updated_at 
 	FROM interviews 
 	WHERE 1=1
 	`
@@ -147,7 +151,7 @@ func (r *InterviewRepo) GetAll(filters *models.GetInterviewsFilters) ([]models.A
 	var interviewIDs []uint64
 	for rows.Next() {
 		var interview models.AnalyzeInterview
-		err := rows.Scan(&interview.ID, &interview.CreatedAt, &interview.UpdatedAt)
+		err := rows.Scan(&interview.ID, &interview.Segments, &interview.PromptVersion, &interview.CreatedAt, &interview.UpdatedAt)
 		if err != nil {
 			return nil, nil, fmt.Errorf("failed to scan interview row: %w", err)
 		}
@@ -193,40 +197,66 @@ func (r *InterviewRepo) Update(interview *models.AnalyzeInterview, qaList []mode
 		return fmt.Errorf("failed to update interview: %w", err)
 	}
 
-	// Delete existing question answers
-	deleteQuery := `DELETE FROM question_answers WHERE interview_id = ?`
-	_, err = tx.Exec(deleteQuery, interview.ID)
-	if err != nil {
-		return fmt.Errorf("failed to delete existing question answers: %w", err)
+	if err = replaceQuestionAnswers(tx, interview.ID, qaList, now); err != nil {
+		return err
 	}
 
-	// Insert new question answers
-	for i := range qaList {
-		qa := &qaList[i]
-		qa.InterviewID = interview.ID
-		qa.CreatedAt = now
-		qa.UpdatedAt = now
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
 
-		qaQuery := `
-		INSERT INTO question_answers (interview_id, question, full_answer, accuracy, reason_unanswered, created_at, updated_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?)
-		`
-		_, err = tx.Exec(qaQuery, qa.InterviewID, qa.Question, qa.FullAnswer, qa.Accuracy, qa.ReasonUnanswered, qa.CreatedAt, qa.UpdatedAt)
-		if err != nil {
-			return fmt.Errorf("failed to insert question answer: %w", err)
+	interview.UpdatedAt = now
+	return nil
+}
+
+// RenameSpeaker renames the speaker in the transcript segments and question answers of an interview
+func (r *InterviewRepo) RenameSpeaker(id uint64, from, to string) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var segments models.TranscriptSegments
+	err = tx.QueryRow(`SELECT segments FROM interviews WHERE id = ?`, id).Scan(&segments)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("no interview found with id: %d", id)
 		}
+		return fmt.Errorf("failed to retrieve interview: %w", err)
+	}
+
+	now := time.Now()
+	segments.RenameSpeaker(from, to)
+
+	_, err = tx.Exec(`UPDATE interviews SET segments = ?, updated_at = ? WHERE id = ?`, segments, now, id)
+	if err != nil {
+		return fmt.Errorf("failed to update interview: %w", err)
+	}
+
+	_, err = tx.Exec(`UPDATE question_answers SET questioner = ?, updated_at = ? WHERE interview_id = ? AND questioner = ?`, to, now, id, from)
+	if err != nil {
+		return fmt.Errorf("failed to rename questioner: %w", err)
+	}
+
+	_, err = tx.Exec(`UPDATE question_answers SET answerer = ?, updated_at = ? WHERE interview_id = ? AND answerer = ?`, to, now, id, from)
+	if err != nil {
+		return fmt.Errorf("failed to rename answerer: %w", err)
 	}
 
 	if err = tx.Commit(); err != nil {
 		return fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
-	interview.UpdatedAt = now
 	return nil
 }
 
-// Delete deletes an interview and its question answers
+// Delete deletes an interview, its question answers and analysis versions
 func (r *InterviewRepo) Delete(id uint64) error {
+	if _, err := db.Exec(`DELETE FROM interview_analyses WHERE interview_id = ?`, id); err != nil {
+		return fmt.Errorf("failed to delete interview analyses: %w", err)
+	}
+
 	query := `DELETE FROM interviews WHERE id = ?`
 	result, err := db.Exec(query, id)
 	if err != nil {
@@ -245,10 +275,130 @@ func (r *InterviewRepo) Delete(id uint64) error {
 	return nil
 }
 
+// SaveAnalysis saves the analysis as the next version of the interview and replaces the question answers
+// of the interview with it. The first saved analysis keeps the one the interview was saved with as version 1.
+func (r *InterviewRepo) SaveAnalysis(analysis *models.InterviewAnalysis) error {
+	interview, qaList, err := r.Get(analysis.InterviewID)
+	if err != nil {
+		return err
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var latest int
+	err = tx.QueryRow(`SELECT COALESCE(MAX(version), 0) FROM interview_analyses WHERE interview_id = ?`, interview.ID).Scan(&latest)
+	if err != nil {
+		return fmt.Errorf("failed to get interview analysis version: %w", err)
+	}
+
+	query := `
+	INSERT INTO interview_analyses (interview_id, version, model, prompt_version, language, qa, created_at)
+	VALUES (?, ?, ?, ?, ?, ?, ?)
+	`
+	if latest == 0 {
+		_, err = tx.Exec(query, interview.ID, 1, "", interview.PromptVersion, "", models.QuestionAnswers(qaList), interview.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to keep the first interview analysis: %w", err)
+		}
+		latest = 1
+	}
+
+	now := time.Now()
+	_, err = tx.Exec(`UPDATE interviews SET prompt_version = ?, updated_at = ? WHERE id = ?`, analysis.PromptVersion, now, interview.ID)
+	if err != nil {
+		return fmt.Errorf("failed to update interview: %w", err)
+	}
+
+	if err = replaceQuestionAnswers(tx, interview.ID, analysis.QA, now); err != nil {
+		return err
+	}
+
+	result, err := tx.Exec(query, interview.ID, latest+1, analysis.Model, analysis.PromptVersion, analysis.Language, analysis.QA, now)
+	if err != nil {
+		return fmt.Errorf("failed to insert interview analysis: %w", err)
+	}
+
+	analysisID, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get interview analysis ID: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	analysis.ID = uint64(analysisID)
+	analysis.Version = latest + 1
+	analysis.CreatedAt = now
+
+	return nil
+}
+
+// GetAnalyses retrieves the saved analysis versions of an interview, oldest first
+func (r *InterviewRepo) GetAnalyses(interviewID uint64) ([]models.InterviewAnalysis, error) {
+	query := `
+	SELECT id, interview_id, version, model, prompt_version, language, qa, created_at
+	FROM interview_analyses
+	WHERE interview_id = ?
+	ORDER BY version
+	`
+	rows, err := db.Query(query, interviewID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query interview analyses: %w", err)
+	}
+	defer rows.Close()
+
+	var analyses []models.InterviewAnalysis
+	for rows.Next() {
+		var analysis models.InterviewAnalysis
+		err := rows.Scan(&analysis.ID, &analysis.InterviewID, &analysis.Version, &analysis.Model, &analysis.PromptVersion, &analysis.Language, &analysis.QA, &analysis.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan interview analysis row: %w", err)
+		}
+		analyses = append(analyses, analysis)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate interview analysis rows: %w", err)
+	}
+
+	return analyses, nil
+}
+
+// replaceQuestionAnswers replaces the question answers of the interview in the transaction
+func replaceQuestionAnswers(tx *sql.Tx, interviewID uint64, qaList []models.QuestionAnswer, now time.Time) error {
+	_, err := tx.Exec(`DELETE FROM question_answers WHERE interview_id = ?`, interviewID)
+	if err != nil {
+		return fmt.Errorf("failed to delete existing question answers: %w", err)
+	}
+
+	for i := range qaList {
+		qa := &qaList[i]
+		qa.InterviewID = interviewID
+		qa.CreatedAt = now
+		qa.UpdatedAt = now
+
+		qaQuery := `
+		INSERT INTO question_answers (interview_id, question, full_answer, accuracy, reason_unanswered, questioner, answerer, start_time, end_time, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		`
+		_, err = tx.Exec(qaQuery, qa.InterviewID, qa.Question, qa.FullAnswer, qa.Accuracy, qa.ReasonUnanswered, qa.Questioner, qa.Answerer, qa.StartTime, qa.EndTime, qa.CreatedAt, qa.UpdatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to insert question answer: %w", err)
+		}
+	}
+
+	return nil
+}
+
 // Helper method to get question answers by interview ID
 func (r *InterviewRepo) getQuestionAnswersByInterviewID(interviewID uint64) ([]models.QuestionAnswer, error) {
 	query := `
-	SELECT id, interview_id, question, full_answer, accuracy, reason_unanswered, created_at, updated_at
+	SELECT id, interview_id, question, full_answer, accuracy, reason_unanswered, questioner, answerer, start_time, end_time, created_at, updated_at
 	FROM question_answers 
 	WHERE interview_id = ?
 	ORDER BY id
@@ -262,7 +412,7 @@ func (r *InterviewRepo) getQuestionAnswersByInterviewID(interviewID uint64) ([]m
 	var qaList []models.QuestionAnswer
 	for rows.Next() {
 		var qa models.QuestionAnswer
-		err := rows.Scan(&qa.ID, &qa.InterviewID, &qa.Question, &qa.FullAnswer, &qa.Accuracy, &qa.ReasonUnanswered, &qa.CreatedAt, &qa.UpdatedAt)
+		err := rows.Scan(&qa.ID, &qa.InterviewID, &qa.Question, &qa.FullAnswer, &qa.Accuracy, &qa.ReasonUnanswered, &qa.Questioner, &qa.Answerer, &qa.StartTime, &qa.EndTime, &qa.CreatedAt, &qa.UpdatedAt)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan question answer row: %w", err)
 		}
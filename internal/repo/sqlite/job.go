@@ -0,0 +1,160 @@
+package sqlite
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mrbelka12000/interview_parser/internal/models"
+)
+
+const jobColumns = `id, type, status, file_path, transcript_path, language, chunks, transcript, batches, result_id, error, created_at, updated_at`
+
+type JobRepo struct{}
+
+func NewJobRepo() *JobRepo {
+	return &JobRepo{}
+}
+
+// Create creates a new job record
+func (r *JobRepo) Create(job *models.Job) (uint64, error) {
+	now := time.Now()
+	query := `
+	INSERT INTO jobs (type, status, file_path, transcript_path, language, chunks, transcript, batches, result_id, error, created_at, updated_at)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	result, err := db.Exec(query, job.Type, job.Status, job.FilePath, job.TranscriptPath, job.Language, job.Chunks, job.Transcript, job.Batches, job.ResultID, job.Error, now, now)
+	if err != nil {
+		return 0, fmt.Errorf("failed to insert job: %w", err)
+	}
+
+	jobID, err := result.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get job ID: %w", err)
+	}
+
+	job.ID = uint64(jobID)
+	job.CreatedAt = now
+	job.UpdatedAt = now
+
+	return job.ID, nil
+}
+
+// Get retrieves a job by ID
+func (r *JobRepo) Get(id uint64) (*models.Job, error) {
+	query := `SELECT ` + jobColumns + ` FROM jobs WHERE id = ?`
+
+	job, err := scanJob(db.QueryRow(query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("no job found with id: %d", id)
+		}
+		return nil, fmt.Errorf("failed to retrieve job: %w", err)
+	}
+
+	return job, nil
+}
+
+// GetAll retrieves the latest jobs
+func (r *JobRepo) GetAll(limit int) ([]models.Job, error) {
+	query := `SELECT ` + jobColumns + ` FROM jobs ORDER BY created_at DESC, id DESC`
+
+	args := []interface{}{}
+	if limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, limit)
+	}
+
+	return queryJobs(query, args...)
+}
+
+// GetByStatus retrieves the jobs in any of the statuses, oldest first
+func (r *JobRepo) GetByStatus(statuses ...models.JobStatus) ([]models.Job, error) {
+	if len(statuses) == 0 {
+		return nil, nil
+	}
+
+	args := make([]interface{}, len(statuses))
+	for i, status := range statuses {
+		args[i] = status
+	}
+
+	query := `SELECT ` + jobColumns + ` FROM jobs WHERE status IN (?` + strings.Repeat(", ?", len(statuses)-1) + `) ORDER BY created_at, id`
+
+	return queryJobs(query, args...)
+}
+
+// Update updates the state of an existing job
+func (r *JobRepo) Update(job *models.Job) error {
+	now := time.Now()
+	query := `
+	UPDATE jobs
+	SET status = ?, transcript_path = ?, language = ?, chunks = ?, transcript = ?, batches = ?, result_id = ?, error = ?, updated_at = ?
+	WHERE id = ?
+	`
+
+	result, err := db.Exec(query, job.Status, job.TranscriptPath, job.Language, job.Chunks, job.Transcript, job.Batches, job.ResultID, job.Error, now, job.ID)
+	if err != nil {
+		return fmt.Errorf("failed to update job: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("no job found with id: %d", job.ID)
+	}
+
+	job.UpdatedAt = now
+	return nil
+}
+
+func queryJobs(query string, args ...interface{}) ([]models.Job, error) {
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query jobs: %w", err)
+	}
+	defer rows.Close()
+
+	var jobs []models.Job
+	for rows.Next() {
+		job, err := scanJob(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan job row: %w", err)
+		}
+
+		jobs = append(jobs, *job)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate job rows: %w", err)
+	}
+
+	return jobs, nil
+}
+
+func scanJob(row interface{ Scan(...any) error }) (*models.Job, error) {
+	var (
+		job            models.Job
+		transcriptJSON []byte
+	)
+
+	err := row.Scan(&job.ID, &job.Type, &job.Status, &job.FilePath, &job.TranscriptPath, &job.Language, &job.Chunks, &transcriptJSON, &job.Batches, &job.ResultID, &job.Error, &job.CreatedAt, &job.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if transcriptJSON != nil {
+		job.Transcript = &models.JobTranscript{}
+		if err = job.Transcript.Scan(transcriptJSON); err != nil {
+			return nil, err
+		}
+	}
+
+	return &job, nil
+}
@@ -0,0 +1,92 @@
+package sqlite
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/mrbelka12000/interview_parser/internal/models"
+)
+
+const promptColumns = `id, name, language, version, label, body, created_at`
+
+type PromptRepo struct{}
+
+func NewPromptRepo() *PromptRepo {
+	return &PromptRepo{}
+}
+
+// Create saves the prompt template as the next version of its name and language
+func (r *PromptRepo) Create(prompt *models.PromptTemplate) error {
+	now := time.Now()
+	query := `
+	INSERT INTO prompt_templates (name, language, version, label, body, created_at)
+	SELECT ?, ?, COALESCE(MAX(version), 0) + 1, ?, ?, ?
+	FROM prompt_templates
+	WHERE name = ? AND language = ?
+	`
+
+	result, err := db.Exec(query, prompt.Name, prompt.Language, prompt.Label, prompt.Body, now, prompt.Name, prompt.Language)
+	if err != nil {
+		return fmt.Errorf("failed to insert prompt template: %w", err)
+	}
+
+	promptID, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get prompt template ID: %w", err)
+	}
+
+	if err = db.QueryRow(`SELECT version FROM prompt_templates WHERE id = ?`, promptID).Scan(&prompt.Version); err != nil {
+		return fmt.Errorf("failed to get prompt template version: %w", err)
+	}
+
+	prompt.ID = uint64(promptID)
+	prompt.CreatedAt = now
+
+	return nil
+}
+
+// GetLatest retrieves the latest version of the prompt template, nil when it has none
+func (r *PromptRepo) GetLatest(name string, lang models.Language) (*models.PromptTemplate, error) {
+	query := `SELECT ` + promptColumns + ` FROM prompt_templates WHERE name = ? AND language = ? ORDER BY version DESC LIMIT 1`
+
+	var prompt models.PromptTemplate
+	err := db.QueryRow(query, name, lang).Scan(&prompt.ID, &prompt.Name, &prompt.Language, &prompt.Version, &prompt.Label, &prompt.Body, &prompt.CreatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to retrieve prompt template: %w", err)
+	}
+
+	return &prompt, nil
+}
+
+// GetVersions retrieves all versions of the prompt template, oldest first
+func (r *PromptRepo) GetVersions(name string, lang models.Language) ([]models.PromptTemplate, error) {
+	query := `SELECT ` + promptColumns + ` FROM prompt_templates WHERE name = ? AND language = ? ORDER BY version`
+
+	rows, err := db.Query(query, name, lang)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query prompt templates: %w", err)
+	}
+	defer rows.Close()
+
+	var prompts []models.PromptTemplate
+	for rows.Next() {
+		var prompt models.PromptTemplate
+		err := rows.Scan(&prompt.ID, &prompt.Name, &prompt.Language, &prompt.Version, &prompt.Label, &prompt.Body, &prompt.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan prompt template row: %w", err)
+		}
+
+		prompts = append(prompts, prompt)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate prompt template rows: %w", err)
+	}
+
+	return prompts, nil
+}
@@ -0,0 +1,128 @@
+package sqlite
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mrbelka12000/interview_parser/internal/models"
+)
+
+type UsageRepo struct{}
+
+func NewUsageRepo() *UsageRepo {
+	return &UsageRepo{}
+}
+
+// Create records the usage of a request
+func (r *UsageRepo) Create(usage *models.Usage) error {
+	now := time.Now()
+	query := `
+	INSERT INTO usages (operation, model, audio_seconds, prompt_tokens, completion_tokens, cost, job_id, interview_id, call_id, created_at)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	result, err := db.Exec(query, usage.Operation, usage.Model, usage.AudioSeconds, usage.PromptTokens, usage.CompletionTokens, usage.Cost, usage.JobID, usage.InterviewID, usage.CallID, now)
+	if err != nil {
+		return fmt.Errorf("failed to insert usage: %w", err)
+	}
+
+	usageID, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get usage ID: %w", err)
+	}
+
+	usage.ID = uint64(usageID)
+	usage.CreatedAt = now
+
+	return nil
+}
+
+// LinkJob links the usage of the job requests to the interview or call the job saved
+func (r *UsageRepo) LinkJob(jobID, interviewID, callID uint64) error {
+	_, err := db.Exec(`UPDATE usages SET interview_id = ?, call_id = ? WHERE job_id = ?`, interviewID, callID, jobID)
+	if err != nil {
+		return fmt.Errorf("failed to link usage of job %d: %w", jobID, err)
+	}
+
+	return nil
+}
+
+// GetAll retrieves the usage of the requests matching the filters, oldest first
+func (r *UsageRepo) GetAll(filters *models.GetUsageFilters) ([]models.Usage, error) {
+	where, args := usageWhere(filters)
+	query := `
+	SELECT id, operation, model, audio_seconds, prompt_tokens, completion_tokens, cost, job_id, interview_id, call_id, created_at
+	FROM usages
+	WHERE 1=1
+	` + where + " ORDER BY created_at, id"
+
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query usage: %w", err)
+	}
+	defer rows.Close()
+
+	var usages []models.Usage
+	for rows.Next() {
+		var u models.Usage
+		err = rows.Scan(&u.ID, &u.Operation, &u.Model, &u.AudioSeconds, &u.PromptTokens, &u.CompletionTokens, &u.Cost, &u.JobID, &u.InterviewID, &u.CallID, &u.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan usage row: %w", err)
+		}
+
+		usages = append(usages, u)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate usage rows: %w", err)
+	}
+
+	return usages, nil
+}
+
+// GetCost sums the cost of the requests matching the filters
+func (r *UsageRepo) GetCost(filters *models.GetUsageFilters) (float64, error) {
+	where, args := usageWhere(filters)
+	query := `SELECT COALESCE(SUM(cost), 0) FROM usages WHERE 1=1` + where
+
+	var cost float64
+	if err := db.QueryRow(query, args...).Scan(&cost); err != nil {
+		return 0, fmt.Errorf("failed to sum usage cost: %w", err)
+	}
+
+	return cost, nil
+}
+
+// usageWhere returns the conditions of the filters with their arguments
+func usageWhere(filters *models.GetUsageFilters) (string, []interface{}) {
+	var (
+		where string
+		args  []interface{}
+	)
+	if filters == nil {
+		return where, args
+	}
+
+	if filters.DateFrom != nil {
+		where += " AND created_at >= ?"
+		args = append(args, filters.DateFrom)
+	}
+	if filters.DateTo != nil {
+		where += " AND created_at <= ?"
+		args = append(args, filters.DateTo)
+	}
+	if filters.JobID != 0 {
+		where += " AND job_id = ?"
+		args = append(args, filters.JobID)
+	}
+	if filters.InterviewID != 0 {
+		where += " AND interview_id = ?"
+		args = append(args, filters.InterviewID)
+	}
+	if filters.CallID != 0 {
+		where += " AND call_id = ?"
+		args = append(args, filters.CallID)
+	}
+
+	return where, args
+}
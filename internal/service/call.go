@@ -3,6 +3,7 @@ package service
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/mrbelka12000/interview_parser/internal/models"
@@ -154,3 +155,29 @@ func (s *Service) UpdateCallAnalysis(id uint64, analysis interface{}) error {
 
 	return nil
 }
+
+// RenameCallSpeaker renames a speaker in the transcript segments of a call
+func (s *Service) RenameCallSpeaker(id uint64, from, to string) (*models.Call, error) {
+	if id == 0 {
+		return nil, fmt.Errorf("invalid call ID: %d", id)
+	}
+	if err := validateSpeakerRename(from, to); err != nil {
+		return nil, err
+	}
+	to = strings.TrimSpace(to)
+
+	existingCall, err := s.callRepo.Get(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get existing call: %w", err)
+	}
+
+	if !existingCall.Segments.RenameSpeaker(from, to) {
+		return nil, fmt.Errorf("no speaker %q found in call %d", from, id)
+	}
+
+	if err = s.callRepo.Update(existingCall); err != nil {
+		return nil, fmt.Errorf("failed to rename speaker: %w", err)
+	}
+
+	return existingCall, nil
+}
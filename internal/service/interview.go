@@ -2,6 +2,7 @@ package service
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/mrbelka12000/interview_parser/internal/models"
 )
@@ -11,18 +12,8 @@ func (s *Service) SaveInterview(interview *models.AnalyzeInterviewWithQA) error
 	if interview == nil {
 		return fmt.Errorf("interview cannot be nil")
 	}
-	if len(interview.QA) == 0 {
-		return fmt.Errorf("question answers list cannot be empty")
-	}
-
-	// Validate question answers
-	for i, qa := range interview.QA {
-		if qa.Question == "" {
-			return fmt.Errorf("question at index %d cannot be empty", i)
-		}
-		if qa.Accuracy < 0 || qa.Accuracy > 100 {
-			return fmt.Errorf("accuracy at index %d must be between 0 and 100", i)
-		}
+	if err := validateQuestionAnswers(interview.QA); err != nil {
+		return err
 	}
 
 	return s.interviewRepo.Save(interview)
@@ -36,21 +27,28 @@ func (s *Service) UpdateInterview(interview *models.AnalyzeInterview, qaList []m
 	if interview.ID == 0 {
 		return fmt.Errorf("invalid interview ID: %d", interview.ID)
 	}
-	if len(qaList) == 0 {
-		return fmt.Errorf("question answers list cannot be empty")
+	if err := validateQuestionAnswers(qaList); err != nil {
+		return err
 	}
 
-	// Validate question answers
-	for i, qa := range qaList {
-		if qa.Question == "" {
-			return fmt.Errorf("question at index %d cannot be empty", i)
-		}
-		if qa.Accuracy < 0 || qa.Accuracy > 100 {
-			return fmt.Errorf("accuracy at index %d must be between 0 and 100", i)
-		}
+	return s.interviewRepo.Update(interview, qaList)
+}
+
+// RenameInterviewSpeaker renames a speaker in the transcript segments and question answers of an interview
+func (s *Service) RenameInterviewSpeaker(id uint64, from, to string) (*models.AnalyzeInterviewWithQA, error) {
+	if id == 0 {
+		return nil, fmt.Errorf("invalid interview ID: %d", id)
+	}
+	if err := validateSpeakerRename(from, to); err != nil {
+		return nil, err
 	}
+	to = strings.TrimSpace(to)
 
-	return s.interviewRepo.Update(interview, qaList)
+	if err := s.interviewRepo.RenameSpeaker(id, from, to); err != nil {
+		return nil, err
+	}
+
+	return s.GetInterview(id)
 }
 
 // DeleteInterview deletes an interview and its question answers
@@ -70,14 +68,18 @@ func (s *Service) GetInterview(id uint64) (*models.AnalyzeInterviewWithQA, error
 	}
 
 	return &models.AnalyzeInterviewWithQA{
-		ID:        interview.ID,
-		QA:        qaList,
-		CreatedAt: interview.CreatedAt,
-		UpdatedAt: interview.UpdatedAt,
+		ID:            interview.ID,
+		QA:            qaList,
+		Transcript:    interview.Transcript,
+		Segments:      interview.Segments,
+		PromptVersion: interview.PromptVersion,
+		CreatedAt:     interview.CreatedAt,
+		UpdatedAt:     interview.UpdatedAt,
 	}, nil
 }
 
-// GetAllInterviews retrieves all interviews with their question answers combined
+// GetAllInterviews retrieves all interviews with their question answers combined.
+// The transcripts are left out of the list, GetInterview reads the transcript of one.
 func (s *Service) GetAllInterviews(filters *models.GetInterviewsFilters) ([]models.AnalyzeInterviewWithQA, error) {
 	interviews, qaLists, err := s.interviewRepo.GetAll(filters)
 	if err != nil {
@@ -87,12 +89,83 @@ func (s *Service) GetAllInterviews(filters *models.GetInterviewsFilters) ([]mode
 	var result []models.AnalyzeInterviewWithQA
 	for i, interview := range interviews {
 		result = append(result, models.AnalyzeInterviewWithQA{
-			ID:        interview.ID,
-			QA:        qaLists[i],
-			CreatedAt: interview.CreatedAt,
-			UpdatedAt: interview.UpdatedAt,
+			ID:            interview.ID,
+			QA:            qaLists[i],
+			Segments:      interview.Segments,
+			PromptVersion: interview.PromptVersion,
+			CreatedAt:     interview.CreatedAt,
+			UpdatedAt:     interview.UpdatedAt,
 		})
 	}
 
 	return result, nil
 }
+
+// SaveInterviewAnalysis saves the analysis as the next version of the interview and makes it the current one.
+// The analysis the interview was saved with is kept as version 1.
+func (s *Service) SaveInterviewAnalysis(analysis *models.InterviewAnalysis) error {
+	if analysis == nil {
+		return fmt.Errorf("analysis cannot be nil")
+	}
+	if analysis.InterviewID == 0 {
+		return fmt.Errorf("invalid interview ID: %d", analysis.InterviewID)
+	}
+	if err := validateQuestionAnswers(analysis.QA); err != nil {
+		return err
+	}
+
+	return s.interviewRepo.SaveAnalysis(analysis)
+}
+
+// GetInterviewAnalyses returns the analysis versions of an interview, oldest first.
+// An interview that was never re-analyzed has its only analysis as version 1.
+func (s *Service) GetInterviewAnalyses(id uint64) ([]models.InterviewAnalysis, error) {
+	analyses, err := s.interviewRepo.GetAnalyses(id)
+	if err != nil {
+		return nil, err
+	}
+	if len(analyses) > 0 {
+		return analyses, nil
+	}
+
+	interview, err := s.GetInterview(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return []models.InterviewAnalysis{{
+		InterviewID:   interview.ID,
+		Version:       1,
+		PromptVersion: interview.PromptVersion,
+		QA:            interview.QA,
+		CreatedAt:     interview.CreatedAt,
+	}}, nil
+}
+
+// validateQuestionAnswers checks that the list is not empty, every question is set and accuracy is in range
+func validateQuestionAnswers(qaList []models.QuestionAnswer) error {
+	if len(qaList) == 0 {
+		return fmt.Errorf("question answers list cannot be empty")
+	}
+
+	for i, qa := range qaList {
+		if qa.Question == "" {
+			return fmt.Errorf("question at index %d cannot be empty", i)
+		}
+		if qa.Accuracy < 0 || qa.Accuracy > 100 {
+			return fmt.Errorf("accuracy at index %d must be between 0 and 100", i)
+		}
+	}
+
+	return nil
+}
+
+func validateSpeakerRename(from, to string) error {
+	if strings.TrimSpace(from) == "" {
+		return fmt.Errorf("speaker name cannot be empty")
+	}
+	if strings.TrimSpace(to) == "" {
+		return fmt.Errorf("new speaker name cannot be empty")
+	}
+	return nil
+}
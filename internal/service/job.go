@@ -0,0 +1,88 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/mrbelka12000/interview_parser/internal/models"
+)
+
+// CreateJob creates a pending job processing the media file, analyzed in the language
+func (s *Service) CreateJob(jobType models.JobType, filePath, transcriptPath string, lang models.Language) (*models.Job, error) {
+	switch jobType {
+	case models.JobTypeInterview, models.JobTypeCall:
+	default:
+		return nil, fmt.Errorf("unknown job type: %q", jobType)
+	}
+	if filePath == "" {
+		return nil, fmt.Errorf("file path cannot be empty")
+	}
+	lang, err := models.ParseLanguage(string(lang))
+	if err != nil {
+		return nil, err
+	}
+
+	job := &models.Job{
+		Type:           jobType,
+		Status:         models.JobStatusPending,
+		FilePath:       filePath,
+		TranscriptPath: transcriptPath,
+		Language:       lang,
+	}
+	if _, err := s.jobRepo.Create(job); err != nil {
+		return nil, fmt.Errorf("failed to create job: %w", err)
+	}
+
+	return job, nil
+}
+
+// GetJob retrieves a job by ID
+func (s *Service) GetJob(id uint64) (*models.Job, error) {
+	if id == 0 {
+		return nil, fmt.Errorf("invalid job ID: %d", id)
+	}
+
+	job, err := s.jobRepo.Get(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job: %w", err)
+	}
+
+	return job, nil
+}
+
+// GetJobs retrieves the latest jobs, all of them when limit is 0
+func (s *Service) GetJobs(limit int) ([]models.Job, error) {
+	if limit < 0 {
+		return nil, fmt.Errorf("limit cannot be negative: %d", limit)
+	}
+
+	jobs, err := s.jobRepo.GetAll(limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get jobs: %w", err)
+	}
+
+	return jobs, nil
+}
+
+// GetUnfinishedJobs retrieves the jobs that were pending or running, e.g. when the app was closed,
+// and the ones paused by the spending limits
+func (s *Service) GetUnfinishedJobs() ([]models.Job, error) {
+	jobs, err := s.jobRepo.GetByStatus(models.JobStatusPending, models.JobStatusRunning, models.JobStatusPaused)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get unfinished jobs: %w", err)
+	}
+
+	return jobs, nil
+}
+
+// UpdateJob saves the state of a job
+func (s *Service) UpdateJob(job *models.Job) error {
+	if job == nil || job.ID == 0 {
+		return fmt.Errorf("invalid job")
+	}
+
+	if err := s.jobRepo.Update(job); err != nil {
+		return fmt.Errorf("failed to update job: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,38 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mrbelka12000/interview_parser/internal/models"
+)
+
+// GetPromptTemplate returns the latest version of the prompt template, nil when the built-in one was not edited
+func (s *Service) GetPromptTemplate(name string, lang models.Language) (*models.PromptTemplate, error) {
+	return s.promptRepo.GetLatest(name, lang)
+}
+
+// GetPromptVersions returns the saved versions of the prompt template, oldest first
+func (s *Service) GetPromptVersions(name string, lang models.Language) ([]models.PromptTemplate, error) {
+	return s.promptRepo.GetVersions(name, lang)
+}
+
+// SavePromptTemplate saves the prompt template as its next version
+func (s *Service) SavePromptTemplate(prompt *models.PromptTemplate) error {
+	if prompt == nil || prompt.Name == "" {
+		return fmt.Errorf("invalid prompt template")
+	}
+	if prompt.Language != models.LanguageRU && prompt.Language != models.LanguageEN {
+		return fmt.Errorf("prompt templates are saved per language, use ru or en")
+	}
+	if strings.TrimSpace(prompt.Body) == "" {
+		return fmt.Errorf("prompt cannot be empty")
+	}
+
+	prompt.Label = strings.TrimSpace(prompt.Label)
+	if err := s.promptRepo.Create(prompt); err != nil {
+		return fmt.Errorf("failed to save prompt template: %w", err)
+	}
+
+	return nil
+}
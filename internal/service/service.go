@@ -9,13 +9,19 @@ type (
 		apiKeyRepo    repo.ApiKeyRepository
 		interviewRepo repo.InterviewRepository
 		callRepo      repo.CallRepository
+		jobRepo       repo.JobRepository
+		usageRepo     repo.UsageRepository
+		promptRepo    repo.PromptRepository
 	}
 )
 
-func New(apiKeyRepo repo.ApiKeyRepository, interviewRepo repo.InterviewRepository, callRepo repo.CallRepository) *Service {
+func New(apiKeyRepo repo.ApiKeyRepository, interviewRepo repo.InterviewRepository, callRepo repo.CallRepository, jobRepo repo.JobRepository, usageRepo repo.UsageRepository, promptRepo repo.PromptRepository) *Service {
 	return &Service{
 		apiKeyRepo:    apiKeyRepo,
 		interviewRepo: interviewRepo,
 		callRepo:      callRepo,
+		jobRepo:       jobRepo,
+		usageRepo:     usageRepo,
+		promptRepo:    promptRepo,
 	}
 }
@@ -0,0 +1,138 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/mrbelka12000/interview_parser/internal/models"
+)
+
+// RecordUsage saves the usage of an API request. A failure is only logged, it never fails the request.
+func (s *Service) RecordUsage(usage models.Usage) {
+	if err := s.usageRepo.Create(&usage); err != nil {
+		log.Printf("[I] Failed to record usage of %s: %v\n", usage.Operation, err)
+	}
+}
+
+// LinkJobUsage links the usage of the job requests to the interview or call saved by the job
+func (s *Service) LinkJobUsage(job *models.Job) error {
+	if job == nil || job.ID == 0 {
+		return fmt.Errorf("invalid job")
+	}
+
+	var interviewID, callID uint64
+	switch job.Type {
+	case models.JobTypeInterview:
+		interviewID = job.ResultID
+	case models.JobTypeCall:
+		callID = job.ResultID
+	}
+
+	return s.usageRepo.LinkJob(job.ID, interviewID, callID)
+}
+
+// GetSpending returns the cost of the requests made this calendar month and of the requests made by the job,
+// job is 0 when jobID is 0
+func (s *Service) GetSpending(jobID uint64) (month, job float64, err error) {
+	now := time.Now()
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.Local)
+
+	month, err = s.usageRepo.GetCost(&models.GetUsageFilters{DateFrom: &monthStart})
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to get monthly spending: %w", err)
+	}
+	if jobID == 0 {
+		return month, 0, nil
+	}
+
+	job, err = s.usageRepo.GetCost(&models.GetUsageFilters{JobID: jobID})
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to get spending of job %d: %w", jobID, err)
+	}
+
+	return month, job, nil
+}
+
+// GetUsage retrieves the usage of the requests matching the filters
+func (s *Service) GetUsage(filters *models.GetUsageFilters) ([]models.Usage, error) {
+	if filters != nil && filters.DateFrom != nil && filters.DateTo != nil && filters.DateFrom.After(*filters.DateTo) {
+		return nil, fmt.Errorf("date from cannot be after date to")
+	}
+
+	usages, err := s.usageRepo.GetAll(filters)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get usage: %w", err)
+	}
+
+	return usages, nil
+}
+
+// GetUsageTotals sums the usage of the requests matching the filters by day, month or model.
+// Days and months are in chronological order, models by descending cost.
+func (s *Service) GetUsageTotals(group models.UsageGroup, filters *models.GetUsageFilters) ([]models.UsageTotal, error) {
+	usages, err := s.GetUsage(filters)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		totals []models.UsageTotal
+		index  = make(map[string]int)
+	)
+	for _, u := range usages {
+		key, err := group.Key(u)
+		if err != nil {
+			return nil, err
+		}
+
+		i, ok := index[key]
+		if !ok {
+			i = len(totals)
+			index[key] = i
+			totals = append(totals, models.UsageTotal{Key: key})
+		}
+		totals[i].Add(u)
+	}
+
+	if group == models.UsageByModel {
+		sort.SliceStable(totals, func(i, j int) bool {
+			return totals[i].Cost > totals[j].Cost
+		})
+	}
+
+	return totals, nil
+}
+
+// GetInterviewUsage sums the usage of the requests that produced the interview
+func (s *Service) GetInterviewUsage(id uint64) (*models.UsageTotal, error) {
+	if id == 0 {
+		return nil, fmt.Errorf("invalid interview ID: %d", id)
+	}
+
+	return s.usageTotal(fmt.Sprintf("interview %d", id), &models.GetUsageFilters{InterviewID: id})
+}
+
+// GetCallUsage sums the usage of the requests that produced the call
+func (s *Service) GetCallUsage(id uint64) (*models.UsageTotal, error) {
+	if id == 0 {
+		return nil, fmt.Errorf("invalid call ID: %d", id)
+	}
+
+	return s.usageTotal(fmt.Sprintf("call %d", id), &models.GetUsageFilters{CallID: id})
+}
+
+func (s *Service) usageTotal(key string, filters *models.GetUsageFilters) (*models.UsageTotal, error) {
+	usages, err := s.GetUsage(filters)
+	if err != nil {
+		return nil, err
+	}
+
+	total := &models.UsageTotal{Key: key}
+	for _, u := range usages {
+		total.Add(u)
+	}
+
+	return total, nil
+}
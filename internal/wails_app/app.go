@@ -13,6 +13,7 @@ import (
 	"github.com/mrbelka12000/interview_parser/internal/config"
 	"github.com/mrbelka12000/interview_parser/internal/delivery/ws"
 	"github.com/mrbelka12000/interview_parser/internal/parser"
+	"github.com/mrbelka12000/interview_parser/internal/pipeline"
 	"github.com/mrbelka12000/interview_parser/internal/repo"
 	"github.com/mrbelka12000/interview_parser/internal/service"
 )
@@ -30,26 +31,31 @@ type FileInfo struct {
 type App struct {
 	ctx           context.Context
 	cfg           *config.Config
-	aiClient      *client.Client
+	aiClient      client.Provider
 	parser        *parser.Parser
 	audioRecorder *audiocapture.AudioCapturer
 	service       *service.Service
+	pipeline      *pipeline.Pipeline
 }
 
 // NewApp creates a new App application struct
 func NewApp(cfg *config.Config) *App {
-	audioRecorder, err := audiocapture.NewAudioCapturer(cfg.AudioSampleRate, cfg.AudioChannels, cfg.AudioBitrate)
+	audioRecorder, err := audiocapture.NewAudioCapturer(cfg.AudioConfig, cfg.RecordingDir)
 	if err != nil {
 		log.Println(fmt.Sprintf("Error creating audio recorder %v", err))
 	}
 
-	return &App{
+	a := &App{
 		cfg:           cfg,
 		parser:        parser.NewParser(cfg),
-		aiClient:      client.New(cfg, ""),
 		audioRecorder: audioRecorder,
 		service:       service.New(repo.NewRepositories(cfg)),
 	}
+	a.aiClient = client.NewProvider(cfg, "", a.service)
+	a.pipeline = pipeline.New(a.cfg, a.parser, a.service, a.aiClient, a.sendProgress)
+	a.recoverRecordings()
+
+	return a
 }
 
 // Startup is called when the app starts. The context is saved
@@ -60,9 +66,13 @@ func (a *App) Startup(ctx context.Context) {
 	sync.OnceFunc(func() {
 		apiKey, err := a.service.GetAPIKey()
 		if err == nil {
-			a.aiClient = client.New(a.cfg, apiKey)
+			a.aiClient = client.NewProvider(a.cfg, apiKey, a.service)
+			a.pipeline.SetAIClient(a.aiClient)
 		}
 
+		// jobs interrupted when the app was closed continue in the background
+		go a.resumeJobs()
+
 		if err := ws.RunServer(a.cfg, a.aiClient); err != nil {
 			log.Println(fmt.Sprintf("Error starting WS server: %v", err))
 		}
@@ -80,17 +90,23 @@ type ProgressReport struct {
 	Stage      string `json:"stage"`
 	Details    string `json:"details"`
 	IsComplete bool   `json:"isComplete"`
+	// JobID is the job being processed, CancelProcessing stops it
+	JobID     uint64 `json:"jobId,omitempty"`
+	Cancelled bool   `json:"cancelled,omitempty"`
 }
 
-// sendProgress sends progress update to frontend
-func (a *App) sendProgress(percentage int, stage, details string) {
-	report := ProgressReport{
+// sendProgress sends progress update of the job to frontend
+func (a *App) sendProgress(jobID uint64, percentage int, stage, details string) {
+	a.emitProgress(ProgressReport{
 		Percentage: percentage,
 		Stage:      stage,
 		Details:    details,
 		IsComplete: percentage >= 100,
-	}
+		JobID:      jobID,
+	})
+}
 
+func (a *App) emitProgress(report ProgressReport) {
 	// Send event to frontend
 	runtime.EventsEmit(a.ctx, "progress", report)
 }
@@ -1,15 +1,13 @@
 package wails_app
 
 import (
-	"context"
-	"errors"
 	"fmt"
-	"log"
 	"os"
 	"path/filepath"
 	"strings"
 	"time"
 
+	"github.com/mrbelka12000/interview_parser/internal/client"
 	"github.com/mrbelka12000/interview_parser/internal/models"
 )
 
@@ -18,6 +16,11 @@ type CallAnalysisResult struct {
 	Success      bool   `json:"success"`
 	Message      string `json:"message"`
 	AnalysisPath string `json:"analysisPath,omitempty"`
+	JobID        uint64 `json:"jobId,omitempty"`
+	// Partial is set when some chunks failed, retrying the job processes them again
+	Partial       bool                `json:"partial,omitempty"`
+	FailedChunks  []models.JobFailure `json:"failedChunks,omitempty"`
+	FailedBatches []models.JobFailure `json:"failedBatches,omitempty"`
 }
 
 // GetAllCallsAPI retrieves all calls with optional pagination
@@ -72,6 +75,11 @@ func (a *App) UpdateCallAnalysisAPI(id uint64, analysis interface{}) error {
 	return a.service.UpdateCallAnalysis(id, analysis)
 }
 
+// RenameCallSpeakerAPI renames a speaker in the transcript of a call
+func (a *App) RenameCallSpeakerAPI(id uint64, from, to string) (*models.Call, error) {
+	return a.service.RenameCallSpeaker(id, from, to)
+}
+
 // SaveCallAPI creates a new call with transcript and optional analysis
 func (a *App) SaveCallAPI(call *models.Call) (*models.Call, error) {
 	return a.service.SaveCall(call)
@@ -98,14 +106,21 @@ func (a *App) SaveAndProcessRecordingForCall(filename string) (*CallAnalysisResu
 	}
 
 	// Then process the saved file for call analysis
-	return a.ProcessFileForCallAnalysis(saveResult.FilePath)
+	return a.ProcessFileForCallAnalysis(saveResult.FilePath, "")
 }
 
-// ProcessFileForCallAnalysis processes file for call analysis
-func (a *App) ProcessFileForCallAnalysis(filePath string) (*CallAnalysisResult, error) {
+// ProcessFileForCallAnalysis processes file for call analysis.
+// language is ru, en, or auto or empty for the configured language
+func (a *App) ProcessFileForCallAnalysis(filePath, language string) (*CallAnalysisResult, error) {
 	fmt.Printf("Processing file for call analysis %s\n", filePath)
 
-	defer os.Remove(filePath)
+	lang, err := models.ParseLanguage(language)
+	if err != nil {
+		return &CallAnalysisResult{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
 
 	// Check if file exists
 	if _, err := os.Stat(filePath); os.IsNotExist(err) {
@@ -116,7 +131,7 @@ func (a *App) ProcessFileForCallAnalysis(filePath string) (*CallAnalysisResult,
 	}
 
 	apiKey, err := a.service.GetAPIKey()
-	if err != nil || apiKey == "" {
+	if client.RequiresAPIKey(a.cfg) && (err != nil || apiKey == "") {
 		return &CallAnalysisResult{
 			Success: false,
 			Message: "No API Key provided",
@@ -135,34 +150,35 @@ func (a *App) ProcessFileForCallAnalysis(filePath string) (*CallAnalysisResult,
 	baseName := strings.TrimSuffix(filepath.Base(filePath), filepath.Ext(filePath))
 	analysisCallPath := filepath.Join(a.cfg.DefaultAnalyzeCallDir, fmt.Sprintf("%s_call_analysis_%v.md", baseName, len(dir)))
 
-	transcript, err := a.transcribeFile(filePath)
-	if err != nil {
-		return &CallAnalysisResult{
-			Message: err.Error(),
-		}, nil
-	}
+	job, err := a.pipeline.StartJob(a.ctx, models.JobTypeCall, filePath, "", lang)
+	a.jobFinished(job, err)
+	if partial, ok := partialError(err); ok {
+		a.sendProgress(job.ID, 100, "Complete!", partialMessage(job.ID, partial))
 
-	if errors.Is(a.ctx.Err(), context.Canceled) {
-		log.Println("[i] cancelled by signal, skip analyze")
 		return &CallAnalysisResult{
-			Success: false,
-			Message: "processing was cancelled",
+			Success:       true,
+			Message:       partialMessage(job.ID, partial),
+			AnalysisPath:  analysisCallPath,
+			JobID:         job.ID,
+			Partial:       true,
+			FailedChunks:  partial.Chunks,
+			FailedBatches: partial.Batches,
 		}, nil
 	}
-
-	err = a.analyzeCall(transcript)
 	if err != nil {
 		return &CallAnalysisResult{
-			Message: err.Error(),
+			Message: jobMessage(err),
+			JobID:   jobID(job),
 		}, nil
 	}
 
 	// Final progress
-	a.sendProgress(100, "Complete!", "Call analysis finished successfully!")
+	a.sendProgress(job.ID, 100, "Complete!", "Call analysis finished successfully!")
 
 	return &CallAnalysisResult{
 		Success:      true,
 		Message:      "File processed successfully",
 		AnalysisPath: analysisCallPath,
+		JobID:        job.ID,
 	}, nil
 }
@@ -7,7 +7,9 @@ import (
 	"strings"
 	"time"
 
+	"github.com/mrbelka12000/interview_parser/internal/client"
 	"github.com/mrbelka12000/interview_parser/internal/models"
+	"github.com/mrbelka12000/interview_parser/internal/pipeline"
 )
 
 // TranscriptionResult represents the result of transcription and analysis
@@ -16,6 +18,18 @@ type TranscriptionResult struct {
 	Message        string `json:"message"`
 	TranscriptPath string `json:"transcriptPath,omitempty"`
 	AnalysisPath   string `json:"analysisPath,omitempty"`
+	JobID          uint64 `json:"jobId,omitempty"`
+	// Partial is set when some chunks or batches failed, retrying the job processes them again
+	Partial       bool                `json:"partial,omitempty"`
+	FailedChunks  []models.JobFailure `json:"failedChunks,omitempty"`
+	FailedBatches []models.JobFailure `json:"failedBatches,omitempty"`
+}
+
+// ReanalyzeResult represents the result of re-analyzing an interview
+type ReanalyzeResult struct {
+	Success   bool                           `json:"success"`
+	Message   string                         `json:"message"`
+	Interview *models.AnalyzeInterviewWithQA `json:"interview,omitempty"`
 }
 
 func (a *App) SaveInterviewAPI(interview *models.AnalyzeInterviewWithQA) (int64, error) {
@@ -60,6 +74,42 @@ func (a *App) UpdateInterviewAPI(interview *models.AnalyzeInterview, qaList []mo
 	return a.service.UpdateInterview(interview, qaList)
 }
 
+// RenameInterviewSpeakerAPI renames a speaker in the transcript and question answers of an interview
+func (a *App) RenameInterviewSpeakerAPI(id uint64, from, to string) (*models.AnalyzeInterviewWithQA, error) {
+	return a.service.RenameInterviewSpeaker(id, from, to)
+}
+
+// ReanalyzeInterviewAPI analyzes the stored transcript of an interview again with the model, prompt version and
+// language of the options, keeping the previous analysis as an older version
+func (a *App) ReanalyzeInterviewAPI(id uint64, options pipeline.ReanalyzeOptions) (*ReanalyzeResult, error) {
+	apiKey, err := a.service.GetAPIKey()
+	if client.RequiresAPIKey(a.cfg) && (err != nil || apiKey == "") {
+		return &ReanalyzeResult{
+			Success: false,
+			Message: "No API Key provided",
+		}, nil
+	}
+
+	interview, err := a.pipeline.ReanalyzeInterview(a.ctx, id, options)
+	if err != nil {
+		return &ReanalyzeResult{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &ReanalyzeResult{
+		Success:   true,
+		Message:   "Interview re-analyzed successfully",
+		Interview: interview,
+	}, nil
+}
+
+// GetInterviewAnalysesAPI retrieves the analysis versions of an interview, oldest first
+func (a *App) GetInterviewAnalysesAPI(id uint64) ([]models.InterviewAnalysis, error) {
+	return a.service.GetInterviewAnalyses(id)
+}
+
 // SaveAndProcessRecording saves the recording and immediately processes it for transcription
 func (a *App) SaveAndProcessRecording(filename string) (*TranscriptionResult, error) {
 	// First save the recording
@@ -76,13 +126,22 @@ func (a *App) SaveAndProcessRecording(filename string) (*TranscriptionResult, er
 	}
 
 	// Then process the saved file for transcription
-	return a.ProcessFileForTranscription(saveResult.FilePath)
+	return a.ProcessFileForTranscription(saveResult.FilePath, "")
 }
 
-// ProcessFileForTranscription handles file upload and processing using the parser logic
-func (a *App) ProcessFileForTranscription(filePath string) (*TranscriptionResult, error) {
+// ProcessFileForTranscription handles file upload and processing using the parser logic.
+// language is ru, en, or auto or empty for the configured language
+func (a *App) ProcessFileForTranscription(filePath, language string) (*TranscriptionResult, error) {
 	fmt.Printf("Processing file %s\n", filePath)
 
+	lang, err := models.ParseLanguage(language)
+	if err != nil {
+		return &TranscriptionResult{
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
 	// Check if file exists
 	if _, err := os.Stat(filePath); os.IsNotExist(err) {
 		return &TranscriptionResult{
@@ -92,7 +151,7 @@ func (a *App) ProcessFileForTranscription(filePath string) (*TranscriptionResult
 	}
 
 	apiKey, err := a.service.GetAPIKey()
-	if err != nil || apiKey == "" {
+	if client.RequiresAPIKey(a.cfg) && (err != nil || apiKey == "") {
 		return &TranscriptionResult{
 			Success: false,
 			Message: "No API Key provided",
@@ -111,37 +170,34 @@ func (a *App) ProcessFileForTranscription(filePath string) (*TranscriptionResult
 	baseName := strings.TrimSuffix(filepath.Base(filePath), filepath.Ext(filePath))
 	transcriptPath := filepath.Join(a.cfg.DefaultTranscriptDir, fmt.Sprintf("%s_transcript_%v.txt", baseName, len(dir)))
 
-	transcript, err := a.transcribeFile(filePath)
-	if err != nil {
-		return &TranscriptionResult{
-			Message: err.Error(),
-		}, nil
-	}
+	job, err := a.pipeline.StartJob(a.ctx, models.JobTypeInterview, filePath, transcriptPath, lang)
+	if partial, ok := partialError(err); ok {
+		a.sendProgress(job.ID, 100, "Complete!", partialMessage(job.ID, partial))
 
-	transcript = a.parser.FormatText(transcript)
-
-	// Step 4: Save transcript
-	a.sendProgress(75, "Saving transcript...", "Writing transcript file...")
-	if err = a.parser.SaveTranscript(transcriptPath, transcript); err != nil {
 		return &TranscriptionResult{
-			Success: false,
-			Message: fmt.Sprintf("failed to save transcript: %s", err),
+			Success:        true,
+			Message:        partialMessage(job.ID, partial),
+			TranscriptPath: transcriptPath,
+			JobID:          job.ID,
+			Partial:        true,
+			FailedChunks:   partial.Chunks,
+			FailedBatches:  partial.Batches,
 		}, nil
 	}
-
-	err = a.analyzeInterview(transcript)
 	if err != nil {
 		return &TranscriptionResult{
-			Message: err.Error(),
+			Message: jobMessage(err),
+			JobID:   jobID(job),
 		}, nil
 	}
 
 	// Final progress
-	a.sendProgress(100, "Complete!", "Processing finished successfully!")
+	a.sendProgress(job.ID, 100, "Complete!", "Processing finished successfully!")
 
 	return &TranscriptionResult{
 		Success:        true,
 		Message:        "File processed successfully",
 		TranscriptPath: transcriptPath,
+		JobID:          job.ID,
 	}, nil
 }
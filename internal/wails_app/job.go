@@ -0,0 +1,128 @@
+package wails_app
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/mrbelka12000/interview_parser/internal/models"
+	"github.com/mrbelka12000/interview_parser/internal/pipeline"
+)
+
+// JobResult represents the result of an action on a processing job
+type JobResult struct {
+	Success bool        `json:"success"`
+	Message string      `json:"message"`
+	Job     *models.Job `json:"job,omitempty"`
+}
+
+// GetJobsAPI returns the latest processing jobs, all of them when limit is 0
+func (a *App) GetJobsAPI(limit int) ([]models.Job, error) {
+	return a.service.GetJobs(limit)
+}
+
+// RetryJob runs a failed, cancelled, paused or interrupted job again, continuing from its last completed chunk or batch
+func (a *App) RetryJob(id uint64) (*JobResult, error) {
+	job, err := a.pipeline.RunJob(a.ctx, id)
+	a.jobFinished(job, err)
+	if partial, ok := partialError(err); ok {
+		return &JobResult{
+			Success: true,
+			Message: partialMessage(id, partial),
+			Job:     job,
+		}, nil
+	}
+	if err != nil {
+		return &JobResult{
+			Message: jobMessage(err),
+			Job:     job,
+		}, nil
+	}
+
+	a.sendProgress(id, 100, "Complete!", fmt.Sprintf("Job %d finished successfully!", id))
+
+	return &JobResult{
+		Success: true,
+		Message: "Job completed successfully",
+		Job:     job,
+	}, nil
+}
+
+// CancelProcessing stops the job: its ffmpeg processes and API requests are interrupted, its chunk files removed
+// and it is marked cancelled, so it is not resumed anymore. The cancelled state is sent with the progress event.
+func (a *App) CancelProcessing(id uint64) (*JobResult, error) {
+	job, err := a.pipeline.CancelJob(id)
+	if err != nil {
+		return &JobResult{
+			Message: err.Error(),
+			Job:     job,
+		}, nil
+	}
+
+	a.jobFinished(job, pipeline.ErrJobCancelled)
+	a.emitProgress(ProgressReport{
+		Stage:      "Cancelled",
+		Details:    fmt.Sprintf("Job %d was cancelled", id),
+		IsComplete: true,
+		JobID:      id,
+		Cancelled:  true,
+	})
+
+	return &JobResult{
+		Success: true,
+		Message: "Job cancelled",
+		Job:     job,
+	}, nil
+}
+
+// resumeJobs continues the jobs interrupted when the app was closed
+func (a *App) resumeJobs() {
+	a.pipeline.ResumeJobs(a.ctx, func(job *models.Job, err error) {
+		a.jobFinished(job, err)
+		if partial, ok := partialError(err); ok {
+			a.sendProgress(job.ID, 100, "Complete!", partialMessage(job.ID, partial))
+		} else if err == nil {
+			a.sendProgress(job.ID, 100, "Complete!", fmt.Sprintf("Resumed job %d finished successfully!", job.ID))
+		}
+	})
+}
+
+// jobFinished removes the recording of a call job once it will not run again,
+// failed and partial jobs keep it for a retry
+func (a *App) jobFinished(job *models.Job, err error) {
+	if job == nil || job.Type != models.JobTypeCall {
+		return
+	}
+	if err != nil && !errors.Is(err, pipeline.ErrJobCancelled) {
+		return
+	}
+
+	for _, path := range a.parser.RecordingFiles(job.FilePath) {
+		os.Remove(path)
+	}
+}
+
+func jobID(job *models.Job) uint64 {
+	if job == nil {
+		return 0
+	}
+	return job.ID
+}
+
+// partialError returns the chunks and batches that failed when the job finished with a usable result
+func partialError(err error) (*pipeline.PartialError, bool) {
+	var partial *pipeline.PartialError
+	ok := errors.As(err, &partial)
+	return partial, ok
+}
+
+func partialMessage(id uint64, partial *pipeline.PartialError) string {
+	return fmt.Sprintf("Processed with %d failed chunks and %d failed batches, retry job %d to process them", len(partial.Chunks), len(partial.Batches), id)
+}
+
+func jobMessage(err error) string {
+	if errors.Is(err, pipeline.ErrJobCancelled) {
+		return "processing was cancelled"
+	}
+	return err.Error()
+}
@@ -48,8 +48,8 @@ func (a *App) SaveOpenAIAPIKey(apiKey string) (*APIKeyResult, error) {
 		}, nil
 	}
 
-	// Basic validation for OpenAI API key
-	if !strings.HasPrefix(apiKey, "sk-") {
+	// Basic validation for OpenAI API key, self-hosted endpoints may use any key format
+	if !a.cfg.UsesCustomEndpoints() && !strings.HasPrefix(apiKey, "sk-") {
 		return &APIKeyResult{
 			Message: "Invalid API key format. OpenAI API keys should start with 'sk-'",
 		}, nil
@@ -58,7 +58,7 @@ func (a *App) SaveOpenAIAPIKey(apiKey string) (*APIKeyResult, error) {
 	fmt.Printf("Saving API key: %s\n", apiKey)
 	// Save to config for current session
 
-	aiClient := client.New(a.cfg, apiKey)
+	aiClient := client.NewProvider(a.cfg, apiKey, a.service)
 	err := aiClient.IsValidAPIKeysProvided()
 	if err != nil {
 		return &APIKeyResult{
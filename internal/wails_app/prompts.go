@@ -0,0 +1,115 @@
+package wails_app
+
+import (
+	"fmt"
+
+	"github.com/mrbelka12000/interview_parser/internal/client"
+	"github.com/mrbelka12000/interview_parser/internal/models"
+)
+
+// PromptVersionsResult is a prompt template with its versions, the built-in one is version 0
+type PromptVersionsResult struct {
+	Success  bool                    `json:"success"`
+	Message  string                  `json:"message,omitempty"`
+	Current  *models.PromptTemplate  `json:"current,omitempty"`
+	Versions []models.PromptTemplate `json:"versions,omitempty"`
+}
+
+// PromptTemplateResult is the result of saving a prompt template
+type PromptTemplateResult struct {
+	Success  bool                   `json:"success"`
+	Message  string                 `json:"message,omitempty"`
+	Template *models.PromptTemplate `json:"template,omitempty"`
+}
+
+// PromptPreviewResult is a prompt template rendered with sample values
+type PromptPreviewResult struct {
+	Success bool                  `json:"success"`
+	Message string                `json:"message,omitempty"`
+	Preview *client.PromptPreview `json:"preview,omitempty"`
+}
+
+// GetPromptSpecsAPI lists the editable prompt templates and their placeholders
+func (a *App) GetPromptSpecsAPI() []client.PromptSpec {
+	return client.PromptSpecs
+}
+
+// GetPromptVersionsAPI returns the versions of the prompt template in the language ("ru" or "en"),
+// the latest one is current
+func (a *App) GetPromptVersionsAPI(name, language string) (*PromptVersionsResult, error) {
+	lang, err := client.PromptLanguage(language)
+	if err != nil {
+		return &PromptVersionsResult{
+			Message: err.Error(),
+		}, nil
+	}
+
+	versions, err := client.PromptVersions(a.service, name, lang)
+	if err != nil {
+		return &PromptVersionsResult{
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &PromptVersionsResult{
+		Success:  true,
+		Current:  &versions[len(versions)-1],
+		Versions: versions,
+	}, nil
+}
+
+// SavePromptTemplateAPI saves the body as the next version of the prompt template in the language,
+// it is used by the analyses from then on
+func (a *App) SavePromptTemplateAPI(name, language, label, body string) (*PromptTemplateResult, error) {
+	lang, err := client.PromptLanguage(language)
+	if err != nil {
+		return &PromptTemplateResult{
+			Message: err.Error(),
+		}, nil
+	}
+	if err = client.ValidatePrompt(name, body); err != nil {
+		return &PromptTemplateResult{
+			Message: err.Error(),
+		}, nil
+	}
+
+	prompt := &models.PromptTemplate{
+		Name:     name,
+		Language: lang,
+		Label:    label,
+		Body:     body,
+	}
+	if err = a.service.SavePromptTemplate(prompt); err != nil {
+		return &PromptTemplateResult{
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &PromptTemplateResult{
+		Success:  true,
+		Message:  fmt.Sprintf("Saved %s", prompt.Ref()),
+		Template: prompt,
+	}, nil
+}
+
+// PreviewPromptAPI renders the body of the prompt template with sample values, as it would be sent to the model
+func (a *App) PreviewPromptAPI(name, language, body string) (*PromptPreviewResult, error) {
+	lang, err := client.PromptLanguage(language)
+	if err != nil {
+		return &PromptPreviewResult{
+			Message: err.Error(),
+		}, nil
+	}
+
+	preview, err := client.PreviewPrompt(name, lang, body)
+	if err != nil {
+		return &PromptPreviewResult{
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &PromptPreviewResult{
+		Success: true,
+		Preview: &preview,
+	}, nil
+}
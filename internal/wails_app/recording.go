@@ -1,12 +1,16 @@
 package wails_app
 
 import (
+	"errors"
 	"fmt"
+	"log"
+	"os"
 	"path/filepath"
 	"strings"
 	"time"
 
 	audiocapture "github.com/mrbelka12000/interview_parser/internal/audio_capture"
+	"github.com/mrbelka12000/interview_parser/internal/models"
 )
 
 // RecordingResult represents the result of audio recording operations
@@ -16,6 +20,13 @@ type RecordingResult struct {
 	FilePath string  `json:"filePath,omitempty"`
 	Duration float64 `json:"duration,omitempty"`
 	DataSize int     `json:"dataSize,omitempty"`
+	// MicTrackPath and SystemTrackPath are set when the sides of the call are saved separately
+	MicTrackPath    string `json:"micTrackPath,omitempty"`
+	SystemTrackPath string `json:"systemTrackPath,omitempty"`
+
+	IsRecording bool                 `json:"isRecording"`
+	IsPaused    bool                 `json:"isPaused"`
+	Pauses      []models.PauseMarker `json:"pauses,omitempty"`
 }
 
 // StartAudioRecording starts recording audio from microphone
@@ -66,9 +77,48 @@ func (a *App) StopAudioRecording() (*RecordingResult, error) {
 		Message:  "Recording stopped successfully",
 		DataSize: info["data_size"].(int),
 		Duration: info["duration_seconds"].(float64),
+		Pauses:   a.audioRecorder.Pauses(),
 	}, nil
 }
 
+// PauseAudioRecording pauses the audio recording, the audio is not recorded until it is resumed
+func (a *App) PauseAudioRecording() (*RecordingResult, error) {
+	if a.audioRecorder == nil {
+		return &RecordingResult{
+			Success: false,
+			Message: "Audio recorder not initialized",
+		}, nil
+	}
+
+	if err := a.audioRecorder.Pause(); err != nil {
+		return &RecordingResult{
+			Success: false,
+			Message: fmt.Sprintf("Failed to pause recording: %s", err),
+		}, nil
+	}
+
+	return a.GetRecordingStatus()
+}
+
+// ResumeAudioRecording resumes a paused audio recording into the same file
+func (a *App) ResumeAudioRecording() (*RecordingResult, error) {
+	if a.audioRecorder == nil {
+		return &RecordingResult{
+			Success: false,
+			Message: "Audio recorder not initialized",
+		}, nil
+	}
+
+	if err := a.audioRecorder.Resume(); err != nil {
+		return &RecordingResult{
+			Success: false,
+			Message: fmt.Sprintf("Failed to resume recording: %s", err),
+		}, nil
+	}
+
+	return a.GetRecordingStatus()
+}
+
 // SaveRecording saves the recorded audio to a file
 func (a *App) SaveRecording(filename string) (*RecordingResult, error) {
 	if a.audioRecorder == nil {
@@ -100,16 +150,68 @@ func (a *App) SaveRecording(filename string) (*RecordingResult, error) {
 		}, nil
 	}
 
+	// the speaker timeline labels the transcript when the recording is processed
+	if err = a.parser.SaveSpeakerTurns(filePath, a.audioRecorder.SpeakerTurns()); err != nil {
+		log.Printf("[I] Failed to save speaker turns: %v\n", err)
+	}
+
+	pauses := a.audioRecorder.Pauses()
+	if len(pauses) > 0 {
+		if err = a.parser.SavePauseMarkers(filePath, pauses); err != nil {
+			log.Printf("[I] Failed to save pause markers: %v\n", err)
+		}
+	}
+
 	// Get audio info
 	info := a.audioRecorder.GetAudioInfo()
 
-	return &RecordingResult{
+	result := &RecordingResult{
 		Success:  true,
 		Message:  "Recording saved successfully",
 		FilePath: filePath,
 		Duration: info["duration_seconds"].(float64),
 		DataSize: info["data_size"].(int),
-	}, nil
+		Pauses:   pauses,
+	}
+
+	if a.audioRecorder.HasTracks() {
+		micPath, systemPath := a.parser.TrackPaths(filePath)
+		if err = a.audioRecorder.SaveTracksAsWAV(micPath, systemPath); err != nil {
+			log.Printf("[I] Failed to save separate tracks: %v\n", err)
+		} else {
+			result.MicTrackPath = micPath
+			result.SystemTrackPath = systemPath
+		}
+	}
+
+	return result, nil
+}
+
+// recoverRecordings saves the recordings left unsaved by a crash next to the other recordings
+func (a *App) recoverRecordings() {
+	recovered, err := audiocapture.RecoverRecordings(a.cfg.RecordingDir)
+	if err != nil {
+		log.Printf("[I] Failed to recover recordings: %v\n", err)
+		return
+	}
+
+	for _, rec := range recovered {
+		filePath := filepath.Join(a.cfg.DefaultAnalyzeCallDir, fmt.Sprintf("recovered_recording_%s.wav", rec.ID))
+		if err = os.Rename(rec.FilePath, filePath); err != nil {
+			log.Printf("[I] Failed to save recovered recording %s: %v\n", rec.ID, err)
+			continue
+		}
+
+		if rec.MicTrackPath != "" {
+			micPath, systemPath := a.parser.TrackPaths(filePath)
+			if err = errors.Join(os.Rename(rec.MicTrackPath, micPath), os.Rename(rec.SystemTrackPath, systemPath)); err != nil {
+				log.Printf("[I] Failed to save tracks of recovered recording %s: %v\n", rec.ID, err)
+			}
+		}
+
+		os.RemoveAll(rec.Dir)
+		log.Printf("[I] Recovered recording saved as %s\n", filePath)
+	}
 }
 
 // DeviceResult represents the result of device operations
@@ -179,9 +281,12 @@ func (a *App) GetRecordingStatus() (*RecordingResult, error) {
 	info := a.audioRecorder.GetAudioInfo()
 
 	return &RecordingResult{
-		Success:  true,
-		Message:  "Status retrieved successfully",
-		DataSize: info["data_size"].(int),
-		Duration: info["duration_seconds"].(float64),
+		Success:     true,
+		Message:     "Status retrieved successfully",
+		DataSize:    info["data_size"].(int),
+		Duration:    info["duration_seconds"].(float64),
+		IsRecording: info["is_capturing"].(bool),
+		IsPaused:    info["is_paused"].(bool),
+		Pauses:      a.audioRecorder.Pauses(),
 	}, nil
 }
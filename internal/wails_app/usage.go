@@ -0,0 +1,87 @@
+package wails_app
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mrbelka12000/interview_parser/internal/models"
+)
+
+// GetUsageTotalsAPI sums the API usage and its estimated cost by "day", "month" or "model",
+// optionally between two dates (2006-01-02, both included)
+func (a *App) GetUsageTotalsAPI(group, dateFrom, dateTo string) ([]models.UsageTotal, error) {
+	filters := &models.GetUsageFilters{}
+
+	if dateFrom != "" {
+		if parsed, err := time.ParseInLocation("2006-01-02", dateFrom, time.Local); err == nil {
+			filters.DateFrom = &parsed
+		}
+	}
+
+	if dateTo != "" {
+		if parsed, err := time.ParseInLocation("2006-01-02", dateTo, time.Local); err == nil {
+			// the whole last day is included
+			parsed = parsed.AddDate(0, 0, 1).Add(-time.Nanosecond)
+			filters.DateTo = &parsed
+		}
+	}
+
+	return a.service.GetUsageTotals(models.UsageGroup(group), filters)
+}
+
+// GetInterviewUsageAPI sums the API usage and estimated cost of processing an interview
+func (a *App) GetInterviewUsageAPI(interviewID uint64) (*models.UsageTotal, error) {
+	return a.service.GetInterviewUsage(interviewID)
+}
+
+// GetCallUsageAPI sums the API usage and estimated cost of processing a call
+func (a *App) GetCallUsageAPI(callID uint64) (*models.UsageTotal, error) {
+	return a.service.GetCallUsage(callID)
+}
+
+// CostEstimateResult is the expected cost of processing a file with the spending so far and its limits
+type CostEstimateResult struct {
+	Success      bool                 `json:"success"`
+	Message      string               `json:"message"`
+	Estimate     *models.CostEstimate `json:"estimate,omitempty"`
+	MonthSpent   float64              `json:"month_spent"`
+	MonthlyLimit float64              `json:"monthly_limit,omitempty"`
+	JobLimit     float64              `json:"job_limit,omitempty"`
+	WithinBudget bool                 `json:"within_budget"`
+}
+
+// EstimateCostAPI estimates the cost of processing the file as an "interview" or a "call" job
+// and checks it against the spending limits before the job is started
+func (a *App) EstimateCostAPI(filePath, jobType string) (*CostEstimateResult, error) {
+	estimate, err := a.pipeline.EstimateCost(a.ctx, models.JobType(jobType), filePath)
+	if err != nil {
+		return &CostEstimateResult{
+			Message: err.Error(),
+		}, nil
+	}
+
+	monthSpent, _, err := a.service.GetSpending(0)
+	if err != nil {
+		return &CostEstimateResult{
+			Message:  err.Error(),
+			Estimate: estimate,
+		}, nil
+	}
+
+	result := &CostEstimateResult{
+		Success:      true,
+		Message:      fmt.Sprintf("Processing is estimated to cost $%.2f", estimate.Total),
+		Estimate:     estimate,
+		MonthSpent:   monthSpent,
+		MonthlyLimit: a.cfg.SpendingMonthlyLimit,
+		JobLimit:     a.cfg.SpendingJobLimit,
+		WithinBudget: true,
+	}
+	if a.cfg.SpendingJobLimit > 0 && estimate.Total > a.cfg.SpendingJobLimit ||
+		a.cfg.SpendingMonthlyLimit > 0 && monthSpent+estimate.Total > a.cfg.SpendingMonthlyLimit {
+		result.WithinBudget = false
+		result.Message = fmt.Sprintf("Processing is estimated to cost $%.2f, over the spending limits", estimate.Total)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,149 @@
+package wav
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+)
+
+// convertReadFrames is how many frames are decoded at once
+const convertReadFrames = 4096
+
+// MonoConverter reads the audio of a Reader as mono 16-bit samples at another sample rate.
+// Channels are averaged; downsampling averages the source frames of every output sample,
+// upsampling interpolates between them.
+type MonoConverter struct {
+	r          *Reader
+	step       float64
+	frameSize  int
+	sampleSize int
+
+	raw    []byte
+	frames []float64 // decoded source frames starting at source frame base
+	base   int64
+	eof    bool
+
+	next int64 // next output sample
+}
+
+// NewMonoConverter creates a converter of the Reader audio to mono at sampleRate
+func NewMonoConverter(r *Reader, sampleRate uint32) (*MonoConverter, error) {
+	switch r.BitsPerSample {
+	case 8, 16, 24, 32:
+	default:
+		return nil, fmt.Errorf("unsupported bits per sample: %d", r.BitsPerSample)
+	}
+	if r.Channels == 0 || r.SampleRate == 0 || sampleRate == 0 {
+		return nil, errors.New("invalid audio format")
+	}
+
+	sampleSize := int(r.BitsPerSample) / 8
+	frameSize := sampleSize * int(r.Channels)
+
+	return &MonoConverter{
+		r:          r,
+		step:       float64(r.SampleRate) / float64(sampleRate),
+		frameSize:  frameSize,
+		sampleSize: sampleSize,
+		raw:        make([]byte, convertReadFrames*frameSize),
+	}, nil
+}
+
+// Read fills dst with converted samples and returns io.EOF after the last one
+func (c *MonoConverter) Read(dst []int16) (int, error) {
+	var n int
+	for n < len(dst) {
+		from := float64(c.next) * c.step
+		to := from + c.step
+
+		// the source frames up to the next output sample are needed
+		if err := c.fill(int64(math.Ceil(to)) + 1); err != nil {
+			return n, err
+		}
+
+		last := c.base + int64(len(c.frames)) - 1
+		if int64(from) > last {
+			if n == 0 {
+				return 0, io.EOF
+			}
+			return n, nil
+		}
+
+		var v float64
+		if c.step > 1 {
+			// average of the source frames covered by the output sample
+			var (
+				sum   float64
+				count int
+			)
+			for i := int64(from); i < int64(math.Ceil(to)) && i <= last; i++ {
+				sum += c.frames[i-c.base]
+				count++
+			}
+			v = sum / float64(count)
+		} else {
+			i := int64(from)
+			frac := from - float64(i)
+			v = c.frames[i-c.base]
+			if i+1 <= last {
+				v += (c.frames[i+1-c.base] - v) * frac
+			}
+		}
+
+		dst[n] = int16(max(min(math.Round(v), math.MaxInt16), math.MinInt16))
+		n++
+		c.next++
+
+		c.drop(int64(float64(c.next) * c.step))
+	}
+
+	return n, nil
+}
+
+// fill decodes source frames until frame upto is buffered or the data ends
+func (c *MonoConverter) fill(upto int64) error {
+	for !c.eof && c.base+int64(len(c.frames)) <= upto {
+		read, err := io.ReadFull(c.r, c.raw)
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			c.eof = true
+		} else if err != nil {
+			return fmt.Errorf("failed to read audio data: %w", err)
+		}
+
+		for off := 0; off+c.frameSize <= read; off += c.frameSize {
+			var sum float64
+			for ch := 0; ch < int(c.r.Channels); ch++ {
+				sum += c.sample(c.raw[off+ch*c.sampleSize:])
+			}
+			c.frames = append(c.frames, sum/float64(c.r.Channels))
+		}
+	}
+
+	return nil
+}
+
+// drop forgets the source frames before frame from
+func (c *MonoConverter) drop(from int64) {
+	if k := from - c.base; k > convertReadFrames {
+		c.frames = append(c.frames[:0], c.frames[k:]...)
+		c.base = from
+	}
+}
+
+// sample decodes one sample scaled to the 16-bit range
+func (c *MonoConverter) sample(b []byte) float64 {
+	switch c.sampleSize {
+	case 1:
+		// 8-bit WAV samples are unsigned
+		return (float64(b[0]) - 128) * 256
+	case 2:
+		return float64(int16(binary.LittleEndian.Uint16(b)))
+	case 3:
+		v := int32(uint32(b[0])<<8|uint32(b[1])<<16|uint32(b[2])<<24) >> 8
+		return float64(v) / 256
+	default:
+		return float64(int32(binary.LittleEndian.Uint32(b))) / 65536
+	}
+}
@@ -0,0 +1,114 @@
+package wav
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"path/filepath"
+	"testing"
+)
+
+// convertAll writes the PCM data as a WAV file and reads it back through a MonoConverter at sampleRate
+func convertAll(t *testing.T, data []byte, srcRate, channels uint32, bits uint16, sampleRate uint32) []int16 {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "source.wav")
+	if err := NewWriter(srcRate, channels, bits).SaveAsWAV(path, data); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := OpenReader(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	conv, err := NewMonoConverter(r, sampleRate)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		out []int16
+		buf = make([]int16, 100)
+	)
+	for {
+		n, err := conv.Read(buf)
+		out = append(out, buf[:n]...)
+		if errors.Is(err, io.EOF) {
+			return out
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestMonoConverterDownsample(t *testing.T) {
+	// 0.1 s of 48 kHz stereo with constant channels
+	data := make([]byte, 4800*4)
+	for i := 0; i < 4800; i++ {
+		binary.LittleEndian.PutUint16(data[i*4:], uint16(int16(1000)))
+		binary.LittleEndian.PutUint16(data[i*4+2:], uint16(int16(3000)))
+	}
+
+	got := convertAll(t, data, 48000, 2, 16, 16000)
+	if len(got) != 1600 {
+		t.Fatalf("got %d samples, want 1600", len(got))
+	}
+	for i, s := range got {
+		if s != 2000 {
+			t.Fatalf("sample %d = %d, want the channel average 2000", i, s)
+		}
+	}
+}
+
+func TestMonoConverterUpsample(t *testing.T) {
+	data := make([]byte, 4*2)
+	for i, v := range []int16{0, 100, 200, 300} {
+		binary.LittleEndian.PutUint16(data[i*2:], uint16(v))
+	}
+
+	got := convertAll(t, data, 8000, 1, 16, 16000)
+	want := []int16{0, 50, 100, 150, 200, 250, 300, 300}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("sample %d = %d, want %d", i, got[i], want[i])
+		}
+	}
+}
+
+func TestMonoConverterSampleFormats(t *testing.T) {
+	tests := []struct {
+		name string
+		bits uint16
+		data []byte
+		want int16
+	}{
+		{name: "8-bit unsigned", bits: 8, data: []byte{192}, want: 16384},
+		{name: "16-bit", bits: 16, data: []byte{0x34, 0x12}, want: 0x1234},
+		{name: "24-bit", bits: 24, data: []byte{0x00, 0x34, 0x12}, want: 0x1234},
+		{name: "32-bit", bits: 32, data: []byte{0x00, 0x00, 0xcc, 0xed}, want: -0x1234},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := convertAll(t, tt.data, 16000, 1, tt.bits, 16000)
+			if len(got) != 1 || got[0] != tt.want {
+				t.Errorf("got %v, want [%d]", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewMonoConverterRejectsUnsupportedFormats(t *testing.T) {
+	if _, err := NewMonoConverter(&Reader{SampleRate: 16000, Channels: 1, BitsPerSample: 12}, 16000); err == nil {
+		t.Error("12-bit audio was accepted")
+	}
+	if _, err := NewMonoConverter(&Reader{SampleRate: 16000, Channels: 0, BitsPerSample: 16}, 16000); err == nil {
+		t.Error("audio without channels was accepted")
+	}
+}
@@ -0,0 +1,161 @@
+package wav
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+const (
+	formatPCM        = 1
+	formatExtensible = 0xFFFE
+)
+
+type (
+	// Reader reads PCM data of a WAV file
+	Reader struct {
+		file *os.File
+		data *io.SectionReader
+
+		riffSize     uint32
+		dataOffset   int64
+		declaredSize uint32
+
+		SampleRate    uint32
+		Channels      uint16
+		BitsPerSample uint16
+		// DataSize is the size of the PCM data in bytes
+		DataSize int64
+	}
+
+	chunkHeader struct {
+		ID   [4]byte
+		Size uint32
+	}
+)
+
+// OpenReader opens a PCM WAV file and positions the reader at the beginning of the audio data
+func OpenReader(filename string) (*Reader, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+
+	r := &Reader{file: file}
+	if err = r.readHeader(); err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return r, nil
+}
+
+func (r *Reader) readHeader() error {
+	var riff struct {
+		ChunkID   [4]byte
+		ChunkSize uint32
+		Format    [4]byte
+	}
+	if err := binary.Read(r.file, binary.LittleEndian, &riff); err != nil {
+		return fmt.Errorf("failed to read RIFF header: %w", err)
+	}
+	if string(riff.ChunkID[:]) != "RIFF" || string(riff.Format[:]) != "WAVE" {
+		return errors.New("not a WAV file")
+	}
+	r.riffSize = riff.ChunkSize
+
+	var (
+		offset  int64 = 12
+		fmtRead bool
+	)
+	for {
+		var chunk chunkHeader
+		if err := binary.Read(r.file, binary.LittleEndian, &chunk); err != nil {
+			return fmt.Errorf("failed to find data chunk: %w", err)
+		}
+		offset += 8
+
+		switch string(chunk.ID[:]) {
+		case "fmt ":
+			var format struct {
+				AudioFormat   uint16
+				NumChannels   uint16
+				SampleRate    uint32
+				ByteRate      uint32
+				BlockAlign    uint16
+				BitsPerSample uint16
+			}
+			if err := binary.Read(r.file, binary.LittleEndian, &format); err != nil {
+				return fmt.Errorf("failed to read format chunk: %w", err)
+			}
+			if format.AudioFormat == formatExtensible && chunk.Size >= 40 {
+				// the actual format is the first two bytes of the sub format GUID
+				var ext struct {
+					Size          uint16
+					ValidBits     uint16
+					ChannelMask   uint32
+					SubFormat     uint16
+					SubFormatRest [14]byte
+				}
+				if err := binary.Read(r.file, binary.LittleEndian, &ext); err != nil {
+					return fmt.Errorf("failed to read format chunk: %w", err)
+				}
+				format.AudioFormat = ext.SubFormat
+			}
+			if format.AudioFormat != formatPCM {
+				return fmt.Errorf("unsupported WAV audio format: %d", format.AudioFormat)
+			}
+			r.SampleRate = format.SampleRate
+			r.Channels = format.NumChannels
+			r.BitsPerSample = format.BitsPerSample
+			fmtRead = true
+
+		case "data":
+			if !fmtRead {
+				return errors.New("data chunk before format chunk")
+			}
+
+			// the size may be wrong for a recording that was not closed, so it is limited by the file size
+			stat, err := r.file.Stat()
+			if err != nil {
+				return fmt.Errorf("failed to stat file: %w", err)
+			}
+			r.dataOffset = offset
+			r.declaredSize = chunk.Size
+			r.DataSize = min(int64(chunk.Size), stat.Size()-offset)
+			r.data = io.NewSectionReader(r.file, offset, r.DataSize)
+			return nil
+		}
+
+		// chunks are padded to an even size
+		offset += int64(chunk.Size) + int64(chunk.Size%2)
+		if _, err := r.file.Seek(offset, io.SeekStart); err != nil {
+			return fmt.Errorf("failed to skip %q chunk: %w", chunk.ID[:], err)
+		}
+	}
+}
+
+// Read reads PCM data
+func (r *Reader) Read(p []byte) (int, error) {
+	return r.data.Read(p)
+}
+
+// ReadAt reads PCM data starting at offset bytes from the beginning of the audio data
+func (r *Reader) ReadAt(p []byte, offset int64) (int, error) {
+	return r.data.ReadAt(p, offset)
+}
+
+// Duration returns the audio duration in seconds
+func (r *Reader) Duration() float64 {
+	bytesPerSecond := int64(r.SampleRate) * int64(r.Channels) * int64(r.BitsPerSample) / 8
+	if bytesPerSecond == 0 {
+		return 0
+	}
+	return float64(r.DataSize) / float64(bytesPerSecond)
+}
+
+func (r *Reader) Close() error {
+	return r.file.Close()
+}
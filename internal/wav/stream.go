@@ -0,0 +1,148 @@
+package wav
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"os"
+)
+
+// streamBufferSize is how much PCM data is buffered before it is written to the file
+const streamBufferSize = 64 * 1024
+
+// StreamWriter appends PCM data to a WAV file as it arrives.
+// The sizes in the header are written on Close, a file left unclosed is fixed by Repair.
+type StreamWriter struct {
+	file     *os.File
+	buf      *bufio.Writer
+	writer   *Writer
+	dataSize int64
+	closed   bool
+}
+
+// NewStreamWriter creates the file and writes the header with empty sizes
+func NewStreamWriter(filename string, sampleRate, channels uint32, bitsPerSample uint16) (*StreamWriter, error) {
+	file, err := os.Create(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create file: %w", err)
+	}
+
+	sw := &StreamWriter{
+		file:   file,
+		buf:    bufio.NewWriterSize(file, streamBufferSize),
+		writer: NewWriter(sampleRate, channels, bitsPerSample),
+	}
+
+	// the header goes to the file right away, so even a short recording can be repaired
+	header := sw.writer.header(0)
+	if err = binary.Write(file, binary.LittleEndian, &header); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to write header: %w", err)
+	}
+
+	return sw, nil
+}
+
+// Write appends PCM data
+func (sw *StreamWriter) Write(p []byte) (int, error) {
+	if sw.dataSize+int64(len(p)) > math.MaxUint32-headerSize {
+		return 0, fmt.Errorf("WAV file size limit exceeded")
+	}
+
+	n, err := sw.buf.Write(p)
+	sw.dataSize += int64(n)
+	if err != nil {
+		return n, fmt.Errorf("failed to write audio data: %w", err)
+	}
+
+	return n, nil
+}
+
+// Size returns the size of the PCM data written so far
+func (sw *StreamWriter) Size() int64 {
+	return sw.dataSize
+}
+
+// Close flushes the data and writes the final sizes into the header, closing again does nothing
+func (sw *StreamWriter) Close() error {
+	if sw.closed {
+		return nil
+	}
+	sw.closed = true
+	defer sw.file.Close()
+
+	if err := sw.buf.Flush(); err != nil {
+		return fmt.Errorf("failed to write audio data: %w", err)
+	}
+
+	if err := patchSizes(sw.file, headerSize, uint32(sw.dataSize)); err != nil {
+		return err
+	}
+
+	return sw.file.Close()
+}
+
+// Repair fixes the header sizes of a WAV file that was not closed, e.g. after a crash,
+// and cuts off an incomplete frame at the end. It reports whether the file was changed.
+func Repair(filename string) (bool, error) {
+	r, err := OpenReader(filename)
+	if err != nil {
+		return false, err
+	}
+
+	var (
+		blockAlign = int64(r.Channels) * int64(r.BitsPerSample) / 8
+		dataSize   = r.DataSize
+	)
+	// the data size read from the header of an unclosed file is 0, so the file size is used
+	if stat, err := r.file.Stat(); err == nil {
+		dataSize = stat.Size() - r.dataOffset
+	}
+	if blockAlign > 0 {
+		dataSize -= dataSize % blockAlign
+	}
+	dataSize = min(dataSize, math.MaxUint32-r.dataOffset)
+
+	var (
+		dataOffset = r.dataOffset
+		intact     = int64(r.declaredSize) == dataSize && int64(r.riffSize) == dataOffset+dataSize-8
+	)
+	r.Close()
+
+	if intact {
+		return false, nil
+	}
+
+	file, err := os.OpenFile(filename, os.O_RDWR, 0)
+	if err != nil {
+		return false, fmt.Errorf("failed to open file: %w", err)
+	}
+	defer file.Close()
+
+	if err = file.Truncate(dataOffset + dataSize); err != nil {
+		return false, fmt.Errorf("failed to truncate file: %w", err)
+	}
+	if err = patchSizes(file, dataOffset, uint32(dataSize)); err != nil {
+		return false, err
+	}
+
+	return true, file.Close()
+}
+
+// patchSizes writes the RIFF and the data chunk sizes of a file whose data starts at dataOffset
+func patchSizes(file *os.File, dataOffset int64, dataSize uint32) error {
+	var size [4]byte
+
+	binary.LittleEndian.PutUint32(size[:], uint32(dataOffset)+dataSize-8)
+	if _, err := file.WriteAt(size[:], 4); err != nil {
+		return fmt.Errorf("failed to write RIFF size: %w", err)
+	}
+
+	binary.LittleEndian.PutUint32(size[:], dataSize)
+	if _, err := file.WriteAt(size[:], dataOffset-4); err != nil {
+		return fmt.Errorf("failed to write data size: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,128 @@
+package wav
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// stereoPCM returns frames of 16-bit stereo PCM with distinct left and right samples
+func stereoPCM(frames int) []byte {
+	data := make([]byte, frames*4)
+	for i := 0; i < frames; i++ {
+		binary.LittleEndian.PutUint16(data[i*4:], uint16(int16(i%2000-1000)))
+		binary.LittleEndian.PutUint16(data[i*4+2:], uint16(int16(-(i % 3000))))
+	}
+	return data
+}
+
+func TestStreamWriterRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "round_trip.wav")
+	data := stereoPCM(48000)
+
+	sw, err := NewStreamWriter(path, 48000, 2, 16)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// written in uneven pieces, as the capture callbacks deliver it
+	for rest := data; len(rest) > 0; {
+		n := min(len(rest), 1234)
+		if _, err := sw.Write(rest[:n]); err != nil {
+			t.Fatal(err)
+		}
+		rest = rest[n:]
+	}
+	if err := sw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if got := sw.Size(); got != int64(len(data)) {
+		t.Errorf("Size() = %d, want %d", got, len(data))
+	}
+
+	r, err := OpenReader(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	if r.SampleRate != 48000 || r.Channels != 2 || r.BitsPerSample != 16 {
+		t.Errorf("format = %d Hz, %d channels, %d bits, want 48000 Hz, 2 channels, 16 bits", r.SampleRate, r.Channels, r.BitsPerSample)
+	}
+	if r.DataSize != int64(len(data)) {
+		t.Errorf("DataSize = %d, want %d", r.DataSize, len(data))
+	}
+	if d := r.Duration(); d != 1 {
+		t.Errorf("Duration() = %v, want 1", d)
+	}
+
+	got := make([]byte, len(data))
+	if _, err := r.ReadAt(got, 0); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, data) {
+		t.Error("read PCM data differs from the written one")
+	}
+}
+
+func TestRepair(t *testing.T) {
+	data := stereoPCM(1000)
+
+	t.Run("unclosed file with an incomplete frame", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "crashed.wav")
+
+		sw, err := NewStreamWriter(path, 48000, 2, 16)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// a crash leaves the data on disk with the empty sizes of the header and half of a frame
+		if _, err := sw.Write(append(data, 1, 2)); err != nil {
+			t.Fatal(err)
+		}
+		if err := sw.buf.Flush(); err != nil {
+			t.Fatal(err)
+		}
+		sw.file.Close()
+
+		changed, err := Repair(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !changed {
+			t.Fatal("Repair() reported an intact file")
+		}
+
+		r, err := OpenReader(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer r.Close()
+
+		if r.DataSize != int64(len(data)) || int64(r.declaredSize) != int64(len(data)) {
+			t.Errorf("data size = %d, declared %d, want %d", r.DataSize, r.declaredSize, len(data))
+		}
+		if want := uint32(headerSize + len(data) - 8); r.riffSize != want {
+			t.Errorf("RIFF size = %d, want %d", r.riffSize, want)
+		}
+		if stat, err := os.Stat(path); err != nil || stat.Size() != int64(headerSize+len(data)) {
+			t.Errorf("file size = %v, want %d", stat, headerSize+len(data))
+		}
+
+		if changed, err = Repair(path); err != nil || changed {
+			t.Errorf("second Repair() = %v, %v, want false, nil", changed, err)
+		}
+	})
+
+	t.Run("closed file is left as is", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "closed.wav")
+		if err := NewWriter(48000, 2, 16).SaveAsWAV(path, data); err != nil {
+			t.Fatal(err)
+		}
+
+		changed, err := Repair(path)
+		if err != nil || changed {
+			t.Errorf("Repair() = %v, %v, want false, nil", changed, err)
+		}
+	})
+}
@@ -7,6 +7,9 @@ import (
 	"os"
 )
 
+// headerSize is the size of the header written by Writer
+const headerSize = 44
+
 type (
 	// Writer header structure for PCM audio
 	Writer struct {
@@ -52,29 +55,8 @@ func (w *Writer) SaveAsWAV(filename string, capturedData []byte) error {
 	}
 	defer file.Close()
 
-	// Calculate sizes
-	dataSize := uint32(len(capturedData))
-	headerSize := uint32(44)
-	totalSize := headerSize + dataSize
-
-	// Create WAV header
-	header := WAVHeader{
-		ChunkID:       [4]byte{'R', 'I', 'F', 'F'},
-		ChunkSize:     totalSize - 8, // Total size - 8 bytes for ChunkID and ChunkSize
-		Format:        [4]byte{'W', 'A', 'V', 'E'},
-		Subchunk1ID:   [4]byte{'f', 'm', 't', ' '},
-		Subchunk1Size: 16, // PCM format
-		AudioFormat:   1,  // PCM
-		NumChannels:   uint16(w.channels),
-		SampleRate:    w.sampleRate,
-		ByteRate:      w.sampleRate * uint32(w.channels) * uint32(w.bitsPerSample) / 8,
-		BlockAlign:    uint16(w.channels) * w.bitsPerSample / 8,
-		BitsPerSample: w.bitsPerSample,
-		Subchunk2ID:   [4]byte{'d', 'a', 't', 'a'},
-		Subchunk2Size: dataSize,
-	}
-
 	// Write header
+	header := w.header(uint32(len(capturedData)))
 	err = binary.Write(file, binary.LittleEndian, &header)
 	if err != nil {
 		return fmt.Errorf("failed to write header: %w", err)
@@ -89,3 +71,22 @@ func (w *Writer) SaveAsWAV(filename string, capturedData []byte) error {
 	log.Printf("[I] Audio saved as WAV file: %s\n", filename)
 	return nil
 }
+
+// header returns the WAV header for dataSize bytes of PCM data
+func (w *Writer) header(dataSize uint32) WAVHeader {
+	return WAVHeader{
+		ChunkID:       [4]byte{'R', 'I', 'F', 'F'},
+		ChunkSize:     headerSize + dataSize - 8, // Total size - 8 bytes for ChunkID and ChunkSize
+		Format:        [4]byte{'W', 'A', 'V', 'E'},
+		Subchunk1ID:   [4]byte{'f', 'm', 't', ' '},
+		Subchunk1Size: 16, // PCM format
+		AudioFormat:   1,  // PCM
+		NumChannels:   uint16(w.channels),
+		SampleRate:    w.sampleRate,
+		ByteRate:      w.sampleRate * uint32(w.channels) * uint32(w.bitsPerSample) / 8,
+		BlockAlign:    uint16(w.channels) * w.bitsPerSample / 8,
+		BitsPerSample: w.bitsPerSample,
+		Subchunk2ID:   [4]byte{'d', 'a', 't', 'a'},
+		Subchunk2Size: dataSize,
+	}
+}
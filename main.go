@@ -9,7 +9,9 @@ import (
 	"github.com/wailsapp/wails/v2/pkg/options"
 	"github.com/wailsapp/wails/v2/pkg/options/assetserver"
 
+	"github.com/mrbelka12000/interview_parser/internal/cli"
 	"github.com/mrbelka12000/interview_parser/internal/config"
+	"github.com/mrbelka12000/interview_parser/internal/delivery/rest"
 	wailsapp "github.com/mrbelka12000/interview_parser/internal/wails_app"
 )
 
@@ -23,7 +25,14 @@ func main() {
 		os.Exit(1)
 	}
 
-	if cfg.ENV == config.ENVLocal {
+	if len(os.Args) > 1 && cli.IsCommand(os.Args[1]) {
+		os.Exit(cli.Run(cfg, os.Args[1:]))
+	}
+
+	fmt.Println(cfg.ServiceConfig.ENV, "starting")
+
+	switch cfg.ENV {
+	case config.ENVLocal:
 		app := wailsapp.NewApp(cfg)
 		// Create application with options
 		err := wails.Run(&options.App{
@@ -41,7 +50,10 @@ func main() {
 		if err != nil {
 			println("Error:", err.Error())
 		}
+	case config.ENVProduction:
+		if err := rest.NewServer(cfg).Run(); err != nil {
+			fmt.Println("Error:", err.Error())
+			os.Exit(1)
+		}
 	}
-
-	fmt.Println(cfg.ServiceConfig.ENV, "starting")
 }